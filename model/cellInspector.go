@@ -0,0 +1,666 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// 单元格内容的格式
+const (
+	formatText = "文本"
+	formatJSON = "JSON"
+	formatXML  = "XML"
+	formatYAML = "YAML"
+)
+
+// partKind 语法着色的类别
+type partKind int
+
+const (
+	partPlain   partKind = iota
+	partKey              // 对象的键
+	partString           // 字符串
+	partNumber           // 数字
+	partLiteral          // true、false、null 等
+	partPunct            // 括号、冒号、逗号等
+	partTag              // XML 标签名
+	partAttr             // XML 属性名
+	partComment          // 注释
+)
+
+// 默认的语法着色样式
+var defaultSyntaxStyles = SyntaxStyles{
+	Key:     lipgloss.NewStyle().Foreground(lipgloss.Color("87")),
+	String:  lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+	Number:  lipgloss.NewStyle().Foreground(lipgloss.Color("215")),
+	Literal: lipgloss.NewStyle().Foreground(lipgloss.Color("176")),
+	Punct:   lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+	Tag:     lipgloss.NewStyle().Foreground(lipgloss.Color("75")),
+	Attr:    lipgloss.NewStyle().Foreground(lipgloss.Color("222")),
+	Comment: lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Italic(true),
+}
+
+// style 返回一类文本的着色样式，普通文本不着色
+func (s SyntaxStyles) style(kind partKind) (lipgloss.Style, bool) {
+	switch kind {
+	case partKey:
+		return s.Key, true
+	case partString:
+		return s.String, true
+	case partNumber:
+		return s.Number, true
+	case partLiteral:
+		return s.Literal, true
+	case partPunct:
+		return s.Punct, true
+	case partTag:
+		return s.Tag, true
+	case partAttr:
+		return s.Attr, true
+	case partComment:
+		return s.Comment, true
+	}
+	return lipgloss.Style{}, false
+}
+
+// inspectPart 一行中着色相同的一段文本
+type inspectPart struct {
+	text string
+	kind partKind
+}
+
+// inspectLine 格式化后的一行
+type inspectLine struct {
+	indent int           // 缩进的空格数
+	parts  []inspectPart // 不含缩进的内容
+	end    int           // 可折叠时为子内容最后一行的索引，否则为 -1
+	closed bool          // end 所在行是否为结束行（如 "}"、"</a>"），折叠后接在省略号之后
+}
+
+// inspectDoc 格式化后的单元格内容
+type inspectDoc struct {
+	format string
+	lines  []inspectLine
+}
+
+// add 追加一行并返回它的索引
+func (d *inspectDoc) add(indent int, parts ...inspectPart) int {
+	d.lines = append(d.lines, inspectLine{indent: indent, parts: parts, end: -1})
+	return len(d.lines) - 1
+}
+
+// appendPart 在最后一行末尾追加内容
+func (d *inspectDoc) appendPart(part inspectPart) {
+	last := &d.lines[len(d.lines)-1]
+	last.parts = append(last.parts, part)
+}
+
+// text 返回格式化后的文本
+func (d inspectDoc) text() string {
+	lines := make([]string, len(d.lines))
+	for i, line := range d.lines {
+		lines[i] = strings.Repeat(" ", line.indent) + partsText(line.parts)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// partsText 返回不带样式的文本
+func partsText(parts []inspectPart) string {
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(p.text)
+	}
+	return b.String()
+}
+
+// renderParts 按类别着色
+func renderParts(parts []inspectPart, styles SyntaxStyles) string {
+	var b strings.Builder
+	for _, p := range parts {
+		if style, ok := styles.style(p.kind); ok {
+			b.WriteString(style.Render(p.text))
+		} else {
+			b.WriteString(p.text)
+		}
+	}
+	return b.String()
+}
+
+// parseInspectDoc 识别 JSON、XML、YAML 并格式化，都不是时按普通文本显示
+func parseInspectDoc(value string) inspectDoc {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if doc, ok := parseJSONDoc(trimmed); ok {
+			return doc
+		}
+	}
+	if strings.HasPrefix(trimmed, "<") {
+		if doc, ok := parseXMLDoc(trimmed); ok {
+			return doc
+		}
+	}
+	if looksLikeYAML(value) {
+		return parseYAMLDoc(value)
+	}
+
+	doc := inspectDoc{format: formatText}
+	for _, line := range strings.Split(value, "\n") {
+		doc.add(0, inspectPart{text: strings.TrimRight(line, "\r")})
+	}
+	return doc
+}
+
+// parseJSONDoc 逐个读取 JSON 记号并按两个空格缩进，保留对象中键的原始顺序
+func parseJSONDoc(s string) (inspectDoc, bool) {
+	if !json.Valid([]byte(s)) {
+		return inspectDoc{}, false
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	doc := inspectDoc{format: formatJSON}
+	if err := doc.jsonValue(dec, nil, 0); err != nil {
+		return inspectDoc{}, false
+	}
+	return doc, true
+}
+
+// jsonValue 读取一个 JSON 值，prefix 为对象中的键
+func (d *inspectDoc) jsonValue(dec *json.Decoder, prefix []inspectPart, indent int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		d.add(indent, append(prefix, jsonScalar(tok))...)
+		return nil
+	}
+
+	closer := "}"
+	if delim == '[' {
+		closer = "]"
+	}
+	open := d.add(indent, append(prefix, inspectPart{string(delim), partPunct})...)
+	for dec.More() {
+		var key []inspectPart
+		if delim == '{' {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := tok.(string)
+			key = []inspectPart{{jsonQuote(name), partKey}, {": ", partPunct}}
+		}
+		if err := d.jsonValue(dec, key, indent+2); err != nil {
+			return err
+		}
+		if dec.More() {
+			d.appendPart(inspectPart{",", partPunct})
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	// 空对象或空数组与开始括号放在同一行
+	if open == len(d.lines)-1 {
+		d.appendPart(inspectPart{closer, partPunct})
+		return nil
+	}
+	d.add(indent, inspectPart{closer, partPunct})
+	d.lines[open].end = len(d.lines) - 1
+	d.lines[open].closed = true
+	return nil
+}
+
+// jsonScalar 返回 JSON 标量的文本和类别
+func jsonScalar(tok json.Token) inspectPart {
+	switch v := tok.(type) {
+	case string:
+		return inspectPart{jsonQuote(v), partString}
+	case json.Number:
+		return inspectPart{v.String(), partNumber}
+	case bool:
+		return inspectPart{strconv.FormatBool(v), partLiteral}
+	}
+	return inspectPart{"null", partLiteral}
+}
+
+// jsonQuote 将字符串编码为 JSON 字符串，不转义 HTML 字符
+func jsonQuote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// parseXMLDoc 按元素层级缩进 XML，只含一段文本的元素显示在同一行
+func parseXMLDoc(s string) (inspectDoc, bool) {
+	dec := xml.NewDecoder(strings.NewReader(s))
+	doc := inspectDoc{format: formatXML}
+	var stack []int
+	elements := 0
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return inspectDoc{}, false
+		}
+		indent := len(stack) * 2
+		switch t := tok.(type) {
+		case xml.StartElement:
+			elements++
+			parts := []inspectPart{{"<", partPunct}, {xmlName(t.Name), partTag}}
+			for _, attr := range t.Attr {
+				parts = append(parts,
+					inspectPart{" " + xmlName(attr.Name), partAttr},
+					inspectPart{"=", partPunct},
+					inspectPart{strconv.Quote(attr.Value), partString})
+			}
+			parts = append(parts, inspectPart{">", partPunct})
+			stack = append(stack, doc.add(indent, parts...))
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return inspectDoc{}, false
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			closing := []inspectPart{{"</", partPunct}, {xmlName(t.Name), partTag}, {">", partPunct}}
+			switch {
+			case open == len(doc.lines)-1:
+				// 没有内容的元素写成 <a/>
+				parts := doc.lines[open].parts
+				parts[len(parts)-1] = inspectPart{"/>", partPunct}
+			case open == len(doc.lines)-2 && isXMLText(doc.lines[open+1]):
+				doc.lines[open].parts = append(doc.lines[open].parts, doc.lines[open+1].parts...)
+				doc.lines[open].parts = append(doc.lines[open].parts, closing...)
+				doc.lines = doc.lines[:open+1]
+			default:
+				doc.add(len(stack)*2, closing...)
+				doc.lines[open].end = len(doc.lines) - 1
+				doc.lines[open].closed = true
+			}
+		case xml.CharData:
+			for _, line := range strings.Split(strings.TrimSpace(string(t)), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					doc.add(indent, inspectPart{line, partPlain})
+				}
+			}
+		case xml.Comment:
+			doc.add(indent, inspectPart{"<!--" + string(t) + "-->", partComment})
+		case xml.ProcInst:
+			doc.add(indent, inspectPart{"<?" + t.Target + " " + string(t.Inst) + "?>", partPunct})
+		case xml.Directive:
+			doc.add(indent, inspectPart{"<!" + string(t) + ">", partPunct})
+		}
+	}
+	if len(stack) > 0 || elements == 0 {
+		return inspectDoc{}, false
+	}
+	return doc, true
+}
+
+// xmlName 返回带前缀的 XML 名称
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+// isXMLText 判断一行是否为元素中的文本
+func isXMLText(line inspectLine) bool {
+	return len(line.parts) == 1 && line.parts[0].kind == partPlain
+}
+
+// YAML 中 "键:" 开头的行
+var yamlKeyPattern = regexp.MustCompile(`^(- )?("[^"]*"|'[^']*'|[^\s#:"'][^:#]*?):(\s+|$)`)
+
+// looksLikeYAML 判断文本是否像 YAML：至少两行，第一行为键或列表项，且多数行为键或列表项
+func looksLikeYAML(s string) bool {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 2 {
+		return false
+	}
+	if lines[0] == "---" {
+		return true
+	}
+	structured := 0
+	for _, line := range lines {
+		if yamlKeyPattern.MatchString(line) || strings.HasPrefix(line, "- ") {
+			structured++
+		}
+	}
+	return yamlKeyPattern.MatchString(lines[0]) && structured*2 > len(lines)
+}
+
+// parseYAMLDoc 保留 YAML 的原有格式，按缩进确定可折叠的范围
+func parseYAMLDoc(s string) inspectDoc {
+	doc := inspectDoc{format: formatYAML}
+	for _, raw := range strings.Split(s, "\n") {
+		raw = strings.ReplaceAll(strings.TrimRight(raw, "\r "), "\t", "  ")
+		content := strings.TrimLeft(raw, " ")
+		indent := len(raw) - len(content)
+		doc.add(indent, yamlParts(content)...)
+	}
+
+	for i := range doc.lines {
+		line := doc.lines[i]
+		if len(line.parts) == 0 {
+			continue
+		}
+		// 键下面的列表项可以与键的缩进相同
+		keyOnly := strings.HasSuffix(partsText(line.parts), ":")
+		end := -1
+		for j := i + 1; j < len(doc.lines); j++ {
+			next := doc.lines[j]
+			if len(next.parts) == 0 {
+				continue
+			}
+			child := next.indent > line.indent ||
+				(keyOnly && next.indent == line.indent && strings.HasPrefix(partsText(next.parts), "- ") &&
+					!strings.HasPrefix(partsText(line.parts), "- "))
+			if !child {
+				break
+			}
+			end = j
+		}
+		doc.lines[i].end = end
+	}
+	return doc
+}
+
+// yamlParts 为一行 YAML 着色
+func yamlParts(content string) []inspectPart {
+	switch {
+	case content == "":
+		return nil
+	case strings.HasPrefix(content, "#"):
+		return []inspectPart{{content, partComment}}
+	case content == "---" || content == "...":
+		return []inspectPart{{content, partPunct}}
+	}
+
+	var parts []inspectPart
+	if m := yamlKeyPattern.FindStringSubmatch(content); m != nil {
+		if m[1] != "" {
+			parts = append(parts, inspectPart{m[1], partPunct})
+		}
+		parts = append(parts, inspectPart{m[2], partKey}, inspectPart{":" + m[3], partPunct})
+		if rest := content[len(m[0]):]; rest != "" {
+			parts = append(parts, yamlScalar(rest))
+		}
+		return parts
+	}
+	if rest, ok := strings.CutPrefix(content, "- "); ok {
+		return append([]inspectPart{{"- ", partPunct}}, yamlScalar(rest))
+	}
+	return []inspectPart{yamlScalar(content)}
+}
+
+// yamlScalar 按值的类型着色
+func yamlScalar(value string) inspectPart {
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "null", "~":
+		return inspectPart{value, partLiteral}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return inspectPart{value, partNumber}
+	}
+	return inspectPart{value, partString}
+}
+
+// cellInspector 单元格查看器的状态
+type cellInspector struct {
+	column string       // 列名
+	raw    string       // 原始值
+	doc    inspectDoc   // 格式化后的内容
+	folded map[int]bool // 已折叠的行
+	cursor int          // 光标所在的可见行
+	offset int          // 第一条显示的可见行
+	status string       // 复制结果等提示
+}
+
+// OpenInspector 打开光标所在单元格的查看器
+func (m *TableModel) OpenInspector() {
+	if m.RowCount == 0 || len(m.TableColumns) == 0 {
+		return
+	}
+	r := m.Cursor.Index()
+	if r >= len(m.OriginalRows) {
+		return
+	}
+	raw := cellAt(m.OriginalRows[r], m.ColCursor)
+	m.inspector = &cellInspector{
+		column: m.columnName(m.ColCursor),
+		raw:    raw,
+		doc:    parseInspectDoc(raw),
+		folded: make(map[int]bool),
+	}
+}
+
+// CloseInspector 关闭单元格查看器
+func (m *TableModel) CloseInspector() {
+	m.inspector = nil
+}
+
+// visibleLines 返回折叠后仍可见的行
+func (in cellInspector) visibleLines() []int {
+	var visible []int
+	for i := 0; i < len(in.doc.lines); i++ {
+		visible = append(visible, i)
+		if end := in.doc.lines[i].end; in.folded[i] && end > i {
+			i = end
+		}
+	}
+	return visible
+}
+
+// updateInspector 处理查看器中的按键：滚动、折叠和复制
+func (m *TableModel) updateInspector(msg tea.KeyMsg) {
+	in := m.inspector
+	visible := in.visibleLines()
+	line := visible[min(in.cursor, len(visible)-1)]
+	foldable := in.doc.lines[line].end > line
+	in.status = ""
+
+	switch {
+	case key.Matches(msg, m.Keys.Inspect), key.Matches(msg, m.Keys.Cancel):
+		m.CloseInspector()
+		return
+	case key.Matches(msg, m.Keys.Up):
+		in.cursor--
+	case key.Matches(msg, m.Keys.Down):
+		in.cursor++
+	case key.Matches(msg, m.Keys.PageUp):
+		in.cursor -= m.inspectorHeight()
+	case key.Matches(msg, m.Keys.PageDown):
+		in.cursor += m.inspectorHeight()
+	case key.Matches(msg, m.Keys.FoldNode):
+		if in.folded[line] {
+			delete(in.folded, line)
+		} else if foldable {
+			in.folded[line] = true
+		}
+	case key.Matches(msg, m.Keys.Left):
+		if foldable && !in.folded[line] {
+			in.folded[line] = true
+		} else {
+			// 跳到上一级
+			for i := in.cursor - 1; i >= 0; i-- {
+				if in.doc.lines[visible[i]].indent < in.doc.lines[line].indent {
+					in.cursor = i
+					break
+				}
+			}
+		}
+	case key.Matches(msg, m.Keys.Right):
+		delete(in.folded, line)
+	case key.Matches(msg, m.Keys.FoldTree):
+		// 有折叠时全部展开，否则折叠第一层以下的所有内容
+		if len(in.folded) > 0 {
+			in.folded = make(map[int]bool)
+		} else {
+			for i, l := range in.doc.lines {
+				if l.end > i && !(i == 0 && l.end == len(in.doc.lines)-1) {
+					in.folded[i] = true
+				}
+			}
+		}
+		in.cursor = 0
+	case key.Matches(msg, m.Keys.Copy):
+		in.status = copyText(in.doc.text(), "已复制格式化后的内容")
+	case key.Matches(msg, m.Keys.CopyRaw):
+		in.status = copyText(in.raw, "已复制原始内容")
+	}
+
+	visible = in.visibleLines()
+	in.cursor = max(min(in.cursor, len(visible)-1), 0)
+	m.scrollInspector(visible)
+}
+
+// copyText 复制文本到剪贴板并返回提示
+func copyText(text, done string) string {
+	if err := clipboard.WriteAll(text); err != nil {
+		return fmt.Sprintf("复制失败: %v", err)
+	}
+	return done
+}
+
+// inspectorWidth 返回内容区的宽度（不含左侧的光标和折叠标记）
+func (m TableModel) inspectorWidth() int {
+	return max(m.viewWidth-6, 20)
+}
+
+// inspectorHeight 返回内容区的高度
+func (m TableModel) inspectorHeight() int {
+	// 标题、信息行和帮助各占一行
+	return max(m.viewHeight-3, 1)
+}
+
+// inspectorRows 渲染一个可见行，过长时折成多行
+func (m TableModel) inspectorRows(line int) []string {
+	in := m.inspector
+	l := in.doc.lines[line]
+	text := renderParts(l.parts, m.Theme.Syntax)
+	if end := l.end; in.folded[line] && end > line {
+		text += m.Theme.Syntax.Comment.Render(" … ")
+		if l.closed {
+			text += renderParts(in.doc.lines[end].parts, m.Theme.Syntax)
+		}
+	}
+	width := max(m.inspectorWidth()-l.indent, 10)
+	rows := strings.Split(ansi.Wrap(text, width, ""), "\n")
+	for i := range rows {
+		rows[i] = strings.Repeat(" ", l.indent) + rows[i]
+	}
+	return rows
+}
+
+// scrollInspector 调整滚动位置使光标行完整可见
+func (m *TableModel) scrollInspector(visible []int) {
+	in := m.inspector
+	if in.cursor < in.offset {
+		in.offset = in.cursor
+	}
+	height := m.inspectorHeight()
+	for in.offset < in.cursor {
+		used := 0
+		for i := in.offset; i <= in.cursor; i++ {
+			used += len(m.inspectorRows(visible[i]))
+		}
+		if used <= height {
+			break
+		}
+		in.offset++
+	}
+}
+
+// inspectorView 全屏显示单元格查看器
+func (m TableModel) inspectorView() string {
+	in := m.inspector
+	visible := in.visibleLines()
+
+	var b strings.Builder
+	b.WriteString(m.Theme.Title.Render(fmt.Sprintf("%s | 第 %d 行 [%s] | 格式: %s", m.Title, m.Cursor.Index()+1, in.column, in.doc.format)))
+	b.WriteString("\n")
+	info := fmt.Sprintf("第 %d/%d 行 | 原始长度 %d", visible[in.cursor]+1, len(in.doc.lines), len([]rune(in.raw)))
+	if len(in.folded) > 0 {
+		info += fmt.Sprintf(" | 已折叠 %d 处", len(in.folded))
+	}
+	if in.status != "" {
+		info += " | " + in.status
+	}
+	b.WriteString(m.Theme.Info.Render(info))
+	b.WriteString("\n")
+
+	height := m.inspectorHeight()
+	var rows []string
+	for i := in.offset; i < len(visible) && len(rows) < height; i++ {
+		line := visible[i]
+		marker := "  "
+		if end := in.doc.lines[line].end; end > line {
+			marker = "▾ "
+			if in.folded[line] {
+				marker = "▸ "
+			}
+		}
+		gutter := "  "
+		if i == in.cursor {
+			gutter = m.Theme.ActiveHeader.Render("›") + " "
+		}
+		for j, row := range m.inspectorRows(line) {
+			if j > 0 {
+				gutter, marker = "  ", "  "
+			}
+			rows = append(rows, gutter+m.Theme.Syntax.Punct.Render(marker)+row)
+		}
+	}
+	if len(rows) > height {
+		rows = rows[:height]
+	}
+	for len(rows) < height {
+		rows = append(rows, "")
+	}
+	b.WriteString(strings.Join(rows, "\n"))
+	b.WriteString("\n")
+
+	help := []string{
+		joinKeyPair(m.Keys.Up.Help().Key, m.Keys.Down.Help().Key) + " 移动",
+		m.Keys.FoldNode.Help().Key + " 折叠/展开",
+		m.Keys.Left.Help().Key + " 折叠或回到上一级",
+		m.Keys.Right.Help().Key + " 展开",
+		m.Keys.FoldTree.Help().Key + " 全部折叠/展开",
+	}
+	// 未启用复制功能时不显示复制按键
+	if m.Keys.Copy.Enabled() {
+		help = append(help, m.Keys.Copy.Help().Key+" 复制格式化内容")
+	}
+	if m.Keys.CopyRaw.Enabled() {
+		help = append(help, m.Keys.CopyRaw.Help().Key+" 复制原始内容")
+	}
+	help = append(help, joinKeyPair(m.Keys.Inspect.Help().Key, m.Keys.Cancel.Help().Key)+" 返回")
+	b.WriteString(m.Theme.Help.Render(strings.Join(help, " | ")))
+	return b.String()
+}
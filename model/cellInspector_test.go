@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestParseInspectDoc 识别 JSON、XML、YAML 并格式化，无法识别或格式错误时按普通文本显示
+func TestParseInspectDoc(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  string
+		format string
+		want   string
+	}{
+		{"JSON 保留键的顺序", `{"b":1,"a":[true,null,"x<y"],"e":{}}`, formatJSON,
+			"{\n  \"b\": 1,\n  \"a\": [\n    true,\n    null,\n    \"x<y\"\n  ],\n  \"e\": {}\n}"},
+		{"JSON 数组", `[1.50, "中文"]`, formatJSON, "[\n  1.50,\n  \"中文\"\n]"},
+		{"不完整的 JSON", `{"a": 1`, formatText, `{"a": 1`},
+		{"XML", `<a x="1"><b>t</b><c></c><!--n--></a>`, formatXML,
+			"<a x=\"1\">\n  <b>t</b>\n  <c/>\n  <!--n-->\n</a>"},
+		{"XML 多段文本", "<a>\n  一\n  二\n</a>", formatXML, "<a>\n  一\n  二\n</a>"},
+		{"标签不配对的 XML", `<a><b></a>`, formatText, `<a><b></a>`},
+		{"YAML 保留原格式", "name: x\nitems:\n- 1\n- two", formatYAML, "name: x\nitems:\n- 1\n- two"},
+		{"只有一行的键值", "a: b", formatText, "a: b"},
+		{"普通文本", "hello\r\nworld", formatText, "hello\nworld"},
+	}
+	for _, c := range cases {
+		doc := parseInspectDoc(c.value)
+		if doc.format != c.format || doc.text() != c.want {
+			t.Errorf("%s: %s\n%s\n期望 %s\n%s", c.name, doc.format, doc.text(), c.format, c.want)
+		}
+	}
+}
+
+// TestParseYAMLFold YAML 按缩进确定可折叠的范围，键下面同缩进的列表项属于该键
+func TestParseYAMLFold(t *testing.T) {
+	doc := parseYAMLDoc("a:\n  b: 1\n  c: 2\nitems:\n- x\n- y\nz: 3")
+	want := []int{2, -1, -1, 5, -1, -1, -1}
+	for i, line := range doc.lines {
+		if line.end != want[i] {
+			t.Errorf("第 %d 行 %q 的折叠范围到 %d，期望 %d", i, partsText(line.parts), line.end, want[i])
+		}
+	}
+}
+
+// TestInspectorKeys 查看器中折叠、展开和跳到上一级
+func TestInspectorKeys(t *testing.T) {
+	data := TableData{Headers: []string{"值"}, Rows: [][]string{{`{"b":1,"a":[true,null,"x"],"e":{}}`}}}
+	cases := []struct {
+		name        string
+		keys        []string
+		wantVisible int
+		wantCursor  int
+		wantLine    string // 光标所在行的文本
+	}{
+		{"打开", nil, 9, 0, "{"},
+		{"折叠", []string{"down", "down", "space"}, 5, 2, `"a": [ … ],`},
+		{"展开", []string{"down", "down", "space", "space"}, 9, 2, `"a": [`},
+		{"左键折叠", []string{"down", "down", "left"}, 5, 2, `"a": [ … ],`},
+		{"左键跳到上一级", []string{"down", "down", "down", "left"}, 9, 2, `"a": [`},
+		{"右键展开", []string{"down", "down", "left", "right"}, 9, 2, `"a": [`},
+		{"不可折叠的行", []string{"down", "space"}, 9, 1, `"b": 1,`},
+		{"全部折叠", []string{"down", "z"}, 5, 0, "{"},
+		{"全部展开", []string{"z", "z"}, 9, 0, "{"},
+		{"光标不超出末尾", []string{"z", "down", "down", "down", "down", "down", "down", "down"}, 5, 4, "}"},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, data), append([]string{"v"}, c.keys...)...)
+		in := m.inspector
+		if in == nil {
+			t.Fatalf("%s: 没有打开查看器", c.name)
+		}
+		visible := in.visibleLines()
+		line := strings.TrimSpace(ansi.Strip(strings.Join(m.inspectorRows(visible[in.cursor]), "")))
+		if len(visible) != c.wantVisible || in.cursor != c.wantCursor || line != c.wantLine {
+			t.Errorf("%s: 可见 %d 行、光标 %d（%q），期望 %d、%d（%q）",
+				c.name, len(visible), in.cursor, line, c.wantVisible, c.wantCursor, c.wantLine)
+		}
+	}
+
+	m := press(t, newTestModel(t, data), "v", "esc")
+	if m.inspector != nil {
+		t.Error("esc 没有关闭查看器")
+	}
+}
@@ -0,0 +1,360 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColumnType 列的数据类型
+type ColumnType int
+
+const (
+	ColumnString   ColumnType = iota // 字符串
+	ColumnInt                        // 整数
+	ColumnFloat                      // 浮点数
+	ColumnBool                       // 布尔值
+	ColumnTime                       // 时间
+	ColumnDuration                   // 时长，如 1h30m
+	ColumnBytes                      // 字节数，如 512KB、1.5GiB
+)
+
+// String 返回类型名称
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnInt:
+		return "int"
+	case ColumnFloat:
+		return "float"
+	case ColumnBool:
+		return "bool"
+	case ColumnTime:
+		return "time"
+	case ColumnDuration:
+		return "duration"
+	case ColumnBytes:
+		return "bytes"
+	}
+	return "string"
+}
+
+// IsNumeric 是否为可做数值运算的类型
+func (t ColumnType) IsNumeric() bool {
+	switch t {
+	case ColumnInt, ColumnFloat, ColumnDuration, ColumnBytes:
+		return true
+	}
+	return false
+}
+
+// Alignment 列内容的对齐方式
+type Alignment int
+
+const (
+	AlignAuto   Alignment = iota // 数值类型右对齐，其余左对齐
+	AlignLeft                    // 左对齐
+	AlignRight                   // 右对齐
+	AlignCenter                  // 居中
+)
+
+// ColumnSpec 列定义
+type ColumnSpec struct {
+	Name     string     // 列名
+	Type     ColumnType // 数据类型
+	Format   string     // 显示格式：数值列为 fmt 格式（如 "%.2f"），时间列为 Go 时间布局，字节列任意非空值表示转换为易读单位
+	Align    Alignment  // 对齐方式
+	MinWidth int        // 最小列宽，0 表示使用全局下限
+	MaxWidth int        // 最大列宽，0 表示使用全局上限
+}
+
+// Value 按列类型解析后的单元格值
+type Value struct {
+	Valid bool      // 是否解析成功，空值或格式不符时为 false
+	Int   int64     // 整数、布尔（0/1），按 int64 保存以免超过 2^53 的值丢失精度
+	Num   float64   // 浮点数、时长（纳秒）、字节数
+	Time  time.Time // 时间
+	Str   string    // 原始文本
+}
+
+// 时间列支持的解析格式
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+}
+
+var bytesPattern = regexp.MustCompile(`(?i)^\s*([0-9]+(?:\.[0-9]+)?)\s*(B|[KMGTP](?:I?B)?)\s*$`)
+
+// 类型推断时采样的最大行数
+const inferSampleSize = 200
+
+// Parse 按列类型解析单元格文本
+func (s ColumnSpec) Parse(cell string) Value {
+	v := Value{Str: cell}
+	text := strings.TrimSpace(cell)
+	if text == "" {
+		return v
+	}
+
+	switch s.Type {
+	case ColumnInt:
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			v.Int, v.Valid = n, true
+		}
+	case ColumnFloat:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			v.Num, v.Valid = f, true
+		}
+	case ColumnBool:
+		if b, ok := parseBool(text); ok {
+			v.Valid = true
+			if b {
+				v.Int = 1
+			}
+		}
+	case ColumnTime:
+		if t, ok := parseTime(text, s.Format); ok {
+			v.Time, v.Valid = t, true
+		}
+	case ColumnDuration:
+		if d, err := time.ParseDuration(text); err == nil {
+			v.Num, v.Valid = float64(d), true
+		}
+	case ColumnBytes:
+		if n, ok := parseBytes(text); ok {
+			v.Num, v.Valid = n, true
+		}
+	default:
+		v.Valid = true
+	}
+	return v
+}
+
+// Compare 比较两个已解析的值，无效值排在有效值之前
+func (s ColumnSpec) Compare(a, b Value) int {
+	if a.Valid != b.Valid {
+		if a.Valid {
+			return 1
+		}
+		return -1
+	}
+	if !a.Valid || s.Type == ColumnString {
+		return strings.Compare(a.Str, b.Str)
+	}
+	switch s.Type {
+	case ColumnTime:
+		return a.Time.Compare(b.Time)
+	case ColumnInt, ColumnBool:
+		switch {
+		case a.Int < b.Int:
+			return -1
+		case a.Int > b.Int:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a.Num < b.Num:
+		return -1
+	case a.Num > b.Num:
+		return 1
+	}
+	return 0
+}
+
+// number 返回数值列的值，用于求和、平均等统计，整数转换为浮点数
+func (s ColumnSpec) number(v Value) float64 {
+	if s.Type == ColumnInt {
+		return float64(v.Int)
+	}
+	return v.Num
+}
+
+// FormatCell 按列格式返回单元格的显示文本，未设置格式或解析失败时返回原文
+func (s ColumnSpec) FormatCell(cell string) string {
+	if s.Format == "" || s.Type == ColumnString {
+		return cell
+	}
+	v := s.Parse(cell)
+	if !v.Valid {
+		return cell
+	}
+	switch s.Type {
+	case ColumnInt:
+		return fmt.Sprintf(s.Format, v.Int)
+	case ColumnFloat:
+		return fmt.Sprintf(s.Format, v.Num)
+	case ColumnTime:
+		return v.Time.Format(s.Format)
+	case ColumnBytes:
+		return humanBytes(v.Num)
+	}
+	return cell
+}
+
+// Position 返回列内容在 lipgloss 中的对齐位置
+func (s ColumnSpec) Position() lipgloss.Position {
+	switch s.Align {
+	case AlignRight:
+		return lipgloss.Right
+	case AlignCenter:
+		return lipgloss.Center
+	case AlignAuto:
+		if s.Type.IsNumeric() {
+			return lipgloss.Right
+		}
+	}
+	return lipgloss.Left
+}
+
+// InferColumnSpecs 采样数据行推断每列类型
+// 一列中所有非空采样值都能解析为同一类型时采用该类型，否则视为字符串
+func InferColumnSpecs(headers []string, rows [][]string) []ColumnSpec {
+	specs := make([]ColumnSpec, len(headers))
+	for i, header := range headers {
+		specs[i] = ColumnSpec{Name: header, Type: inferColumnType(rows, i)}
+	}
+	return specs
+}
+
+// inferColumnType 推断单列类型，候选类型按从具体到宽泛的顺序尝试
+func inferColumnType(rows [][]string, col int) ColumnType {
+	candidates := []ColumnType{ColumnInt, ColumnFloat, ColumnDuration, ColumnBytes, ColumnTime, ColumnBool}
+
+	// 行数较多时均匀采样
+	step := 1
+	if len(rows) > inferSampleSize {
+		step = len(rows) / inferSampleSize
+	}
+
+	seen := 0
+	for r := 0; r < len(rows) && len(candidates) > 0; r += step {
+		if col >= len(rows[r]) || strings.TrimSpace(rows[r][col]) == "" {
+			continue
+		}
+		seen++
+		kept := candidates[:0]
+		for _, t := range candidates {
+			if (ColumnSpec{Type: t}).Parse(rows[r][col]).Valid {
+				kept = append(kept, t)
+			}
+		}
+		candidates = kept
+	}
+
+	if seen == 0 || len(candidates) == 0 {
+		return ColumnString
+	}
+	return candidates[0]
+}
+
+// resolveColumnSpecs 合并调用方提供的列定义与推断结果
+// 未提供列定义时全部推断；提供的列定义少于表头时，缺少的列按推断结果补齐
+func resolveColumnSpecs(data TableData) []ColumnSpec {
+	headers := data.Headers
+	if len(headers) == 0 {
+		for _, spec := range data.Columns {
+			headers = append(headers, spec.Name)
+		}
+	}
+
+	specs := InferColumnSpecs(headers, data.Rows)
+	for i := range specs {
+		if i < len(data.Columns) {
+			spec := data.Columns[i]
+			if spec.Name == "" {
+				spec.Name = specs[i].Name
+			}
+			specs[i] = spec
+		}
+	}
+	return specs
+}
+
+// columnIndexes 按列名（不区分大小写）查找列的索引
+func columnIndexes(names []string, specs []ColumnSpec) ([]int, error) {
+	var cols []int
+	for _, name := range names {
+		col := -1
+		for i, spec := range specs {
+			if strings.EqualFold(spec.Name, name) {
+				col = i
+				break
+			}
+		}
+		if col < 0 {
+			return nil, fmt.Errorf("列 %q 不存在", name)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// parseBool 解析布尔值，仅接受明确的真假文本
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "y", "是":
+		return true, true
+	case "false", "no", "n", "否":
+		return false, true
+	}
+	return false, false
+}
+
+// parseTime 按指定格式或内置格式列表解析时间
+func parseTime(s, layout string) (time.Time, bool) {
+	if layout != "" {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	for _, l := range timeLayouts {
+		if t, err := time.ParseInLocation(l, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseBytes 解析带单位的字节数，单位按 1024 进制换算
+func parseBytes(s string) (float64, bool) {
+	match := bytesPattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	unit := strings.ToUpper(match[2])
+	if unit != "" && unit != "B" {
+		exp := strings.IndexByte("KMGTP", unit[0]) + 1
+		n *= math.Pow(1024, float64(exp))
+	}
+	return n, true
+}
+
+// humanBytes 将字节数转换为易读单位
+func humanBytes(n float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := 0
+	for math.Abs(n) >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
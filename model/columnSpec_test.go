@@ -0,0 +1,76 @@
+package model
+
+import "testing"
+
+// TestInferColumnType 非空采样值都能解析为同一类型时采用该类型，否则为字符串
+func TestInferColumnType(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		want   ColumnType
+	}{
+		{"整数", []string{"1", "-2", "", "9007199254740993"}, ColumnInt},
+		{"浮点数", []string{"1", "2.5", "-0.1"}, ColumnFloat},
+		{"时长", []string{"1h30m", "15s"}, ColumnDuration},
+		{"字节数", []string{"512KB", "1.5GiB", "10 B"}, ColumnBytes},
+		{"时间", []string{"2024-01-02", "2024-01-02 15:04:05"}, ColumnTime},
+		{"布尔", []string{"true", "否"}, ColumnBool},
+		{"混合", []string{"1", "abc"}, ColumnString},
+		{"全空", []string{"", " "}, ColumnString},
+	}
+	for _, c := range cases {
+		rows := make([][]string, len(c.values))
+		for i, v := range c.values {
+			rows[i] = []string{v}
+		}
+		if got := inferColumnType(rows, 0); got != c.want {
+			t.Errorf("%s: 推断为 %s，期望 %s", c.name, got, c.want)
+		}
+	}
+}
+
+// TestColumnSpecCompare 按列类型比较，解析失败的值排在前面
+func TestColumnSpecCompare(t *testing.T) {
+	cases := []struct {
+		typ  ColumnType
+		a, b string
+		want int
+	}{
+		{ColumnString, "10", "9", -1},
+		{ColumnInt, "10", "9", 1},
+		{ColumnInt, "9007199254740993", "9007199254740992", 1},
+		{ColumnInt, "-9223372036854775808", "9223372036854775807", -1},
+		{ColumnInt, "007", "7", 0},
+		{ColumnInt, "", "-1", -1},
+		{ColumnInt, "abc", "1", -1},
+		{ColumnFloat, "2.5", "10", -1},
+		{ColumnBool, "yes", "false", 1},
+		{ColumnDuration, "90s", "1h", -1},
+		{ColumnBytes, "1KB", "1000", 1},
+		{ColumnTime, "2024-01-02", "2023-12-31 23:59:59", 1},
+	}
+	for _, c := range cases {
+		spec := ColumnSpec{Type: c.typ}
+		if got := spec.Compare(spec.Parse(c.a), spec.Parse(c.b)); got != c.want {
+			t.Errorf("%s: Compare(%q, %q) = %d，期望 %d", c.typ, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// TestFormatCellInt 大整数格式化后不丢失精度
+func TestFormatCellInt(t *testing.T) {
+	cases := []struct {
+		format, cell, want string
+	}{
+		{"%d", "9007199254740993", "9007199254740993"},
+		{"%d", "9223372036854775807", "9223372036854775807"},
+		{"%05d", "42", "00042"},
+		{"", "abc", "abc"},
+	}
+	for _, c := range cases {
+		spec := ColumnSpec{Type: ColumnInt, Format: c.format}
+		if got := spec.FormatCell(c.cell); got != c.want {
+			t.Errorf("FormatCell(%q, %q) = %q，期望 %q", c.format, c.cell, got, c.want)
+		}
+	}
+}
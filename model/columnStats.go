@@ -0,0 +1,350 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 字符串列统计中列出的最常见值的个数
+const statsTopN = 10
+
+// valueCount 一个值及其出现次数
+type valueCount struct {
+	value string
+	count int
+}
+
+// columnStats 一列在当前筛选结果中的统计
+type columnStats struct {
+	column   int
+	spec     ColumnSpec
+	count    int    // 行数
+	nonEmpty int    // 非空值个数
+	distinct int    // 不同的非空值个数
+	invalid  int    // 非空但无法按列类型解析的值个数
+	min, max string // 最小值和最大值的原文，按列类型比较
+
+	// 数值列
+	sum, mean, median, p95, stddev float64
+
+	// 非数值列
+	minLen, maxLen int
+	top            []valueCount // 出现次数最多的值，次数相同时按值排序
+}
+
+// statsPanel 列统计面板的状态
+type statsPanel struct {
+	column int
+	result *columnStats       // 计算结果，nil 表示正在计算
+	gen    int                // 计算任务的序号
+	cancel context.CancelFunc // 取消后台计算
+}
+
+// statsComputedMsg 后台统计完成
+type statsComputedMsg struct {
+	gen    int
+	result columnStats
+	err    error
+}
+
+// OpenStats 打开光标所在列的统计面板，数据量大时在后台计算
+func (m *TableModel) OpenStats() tea.Cmd {
+	if len(m.TableColumns) == 0 {
+		return nil
+	}
+	// 显示行尚未计算完成时统计结果不准确
+	if m.viewJob != nil || m.viewPending {
+		m.StatusMsg = "正在排序/筛选，完成后再查看统计"
+		return nil
+	}
+	// 没有数据时显示行中只有“无数据”占位行，不参与统计
+	if m.RowCount == 0 {
+		m.StatusMsg = "没有数据，无法查看统计"
+		return nil
+	}
+	m.CloseStats()
+	m.CloseFrequency()
+	m.ShowDiffDetail = false
+
+	col := m.ColCursor
+	rows, spec := m.OriginalRows, m.columnSpec(col)
+	m.statsGen++
+	panel := &statsPanel{column: col, gen: m.statsGen}
+	m.stats = panel
+	if len(rows) < asyncRowThreshold {
+		result, _ := computeColumnStats(context.Background(), rows, col, spec)
+		panel.result = &result
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	panel.cancel = cancel
+	gen := panel.gen
+	work := func() tea.Msg {
+		result, err := computeColumnStats(ctx, rows, col, spec)
+		return statsComputedMsg{gen: gen, result: result, err: err}
+	}
+	return tea.Batch(work, m.Spinner.Tick)
+}
+
+// CloseStats 关闭统计面板，取消仍在执行的计算
+func (m *TableModel) CloseStats() {
+	if m.stats != nil && m.stats.cancel != nil {
+		m.stats.cancel()
+	}
+	m.stats = nil
+}
+
+// StatsOpen 是否正在显示统计面板
+func (m TableModel) StatsOpen() bool {
+	return m.stats != nil
+}
+
+// statsComputing 列统计或值分布是否正在后台计算
+func (m TableModel) statsComputing() bool {
+	return (m.stats != nil && m.stats.result == nil) || (m.freq != nil && m.freq.values == nil)
+}
+
+// handleStatsComputed 应用后台统计结果，丢弃已被取代的结果
+func (m *TableModel) handleStatsComputed(msg statsComputedMsg) {
+	if m.stats == nil || msg.gen != m.stats.gen || msg.err != nil {
+		return
+	}
+	m.stats.cancel()
+	m.stats.cancel = nil
+	m.stats.result = &msg.result
+}
+
+// updateStats 处理统计面板中的按键：切换列或关闭
+func (m *TableModel) updateStats(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.Keys.Stats), key.Matches(msg, m.Keys.Cancel):
+		m.CloseStats()
+	case key.Matches(msg, m.Keys.Left):
+		if m.ColCursor > 0 {
+			m.MoveColumnCursor(m.ColCursor - 1)
+			return m.OpenStats()
+		}
+	case key.Matches(msg, m.Keys.Right):
+		if m.ColCursor < len(m.TableColumns)-1 {
+			m.MoveColumnCursor(m.ColCursor + 1)
+			return m.OpenStats()
+		}
+	}
+	return nil
+}
+
+// computeColumnStats 统计 rows 中第 col 列，每处理一批行检查一次取消
+func computeColumnStats(ctx context.Context, rows []table.Row, col int, spec ColumnSpec) (columnStats, error) {
+	s := columnStats{column: col, spec: spec, count: len(rows)}
+	numeric := spec.Type.IsNumeric()
+
+	counts := make(map[string]int)
+	var nums []float64
+	var minVal, maxVal Value
+	hasRange := false
+	for i, row := range rows {
+		if i%viewCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return s, err
+			}
+		}
+		cell := cellAt(row, col)
+		if strings.TrimSpace(cell) == "" {
+			continue
+		}
+		s.nonEmpty++
+		counts[cell]++
+
+		if n := DisplayWidth(cell); s.nonEmpty == 1 {
+			s.minLen, s.maxLen = n, n
+		} else {
+			s.minLen, s.maxLen = min(s.minLen, n), max(s.maxLen, n)
+		}
+
+		v := spec.Parse(cell)
+		if !v.Valid {
+			s.invalid++
+			continue
+		}
+		if !hasRange || spec.Compare(v, minVal) < 0 {
+			minVal = v
+		}
+		if !hasRange || spec.Compare(v, maxVal) > 0 {
+			maxVal = v
+		}
+		hasRange = true
+		if numeric {
+			nums = append(nums, spec.number(v))
+		}
+	}
+	s.distinct = len(counts)
+	if hasRange {
+		s.min, s.max = minVal.Str, maxVal.Str
+	}
+
+	if numeric && len(nums) > 0 {
+		sort.Float64s(nums)
+		for _, n := range nums {
+			s.sum += n
+		}
+		s.mean = s.sum / float64(len(nums))
+		var sq float64
+		for _, n := range nums {
+			sq += (n - s.mean) * (n - s.mean)
+		}
+		s.stddev = math.Sqrt(sq / float64(len(nums)))
+		s.median = percentile(nums, 0.5)
+		s.p95 = percentile(nums, 0.95)
+	}
+
+	if !numeric {
+		s.top = make([]valueCount, 0, len(counts))
+		for value, count := range counts {
+			s.top = append(s.top, valueCount{value: value, count: count})
+		}
+		sort.Slice(s.top, func(i, j int) bool {
+			if s.top[i].count != s.top[j].count {
+				return s.top[i].count > s.top[j].count
+			}
+			return s.top[i].value < s.top[j].value
+		})
+		if len(s.top) > statsTopN {
+			s.top = s.top[:statsTopN]
+		}
+	}
+	return s, nil
+}
+
+// percentile 返回已排序数值的百分位数，在相邻两个值之间线性插值
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	return sorted[lo] + (sorted[lo+1]-sorted[lo])*(pos-float64(lo))
+}
+
+// formatStat 按列类型显示统计得到的数值
+func formatStat(spec ColumnSpec, n float64) string {
+	switch spec.Type {
+	case ColumnDuration:
+		return time.Duration(n).Round(time.Millisecond).String()
+	case ColumnBytes:
+		return humanBytes(n)
+	}
+	return strconv.FormatFloat(math.Round(n*1e4)/1e4, 'f', -1, 64)
+}
+
+// statsView 显示统计面板，高度与表格相同
+func (m TableModel) statsView() string {
+	p := m.stats
+	height := m.bodyHeight() + 1
+	title := fmt.Sprintf("列统计: %s（%s 切换列，%s 关闭）", m.columnName(p.column),
+		joinKeyPair(m.Keys.Left.Help().Key, m.Keys.Right.Help().Key),
+		joinKeyPair(m.Keys.Stats.Help().Key, m.Keys.Cancel.Help().Key))
+	lines := []string{m.Theme.Title.Render(title)}
+
+	if p.result == nil {
+		lines = append(lines, m.Theme.Info.Render(fmt.Sprintf("%s统计 %d 行中...", m.Spinner.View(), len(m.OriginalRows))))
+	} else {
+		lines = append(lines, m.statsLines(*p.result, height-1)...)
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statsLines 按“名称 值”的形式排列统计项，宽度足够时排成多列，剩余的行显示最常见的值
+func (m TableModel) statsLines(s columnStats, height int) []string {
+	type item struct{ name, value string }
+	percent := func(n int) string {
+		if s.count == 0 {
+			return fmt.Sprint(n)
+		}
+		return fmt.Sprintf("%d (%.1f%%)", n, float64(n)*100/float64(s.count))
+	}
+	items := []item{
+		{"类型", s.spec.Type.String()},
+		{"行数", fmt.Sprint(s.count)},
+		{"非空", percent(s.nonEmpty)},
+		{"不同值", fmt.Sprint(s.distinct)},
+	}
+	if s.invalid > 0 {
+		items = append(items, item{"无法解析", fmt.Sprint(s.invalid)})
+	}
+	if s.min != "" || s.max != "" {
+		items = append(items,
+			item{"最小值", s.spec.FormatCell(s.min)},
+			item{"最大值", s.spec.FormatCell(s.max)})
+	}
+	if s.spec.Type.IsNumeric() {
+		if s.nonEmpty > s.invalid {
+			items = append(items,
+				item{"总和", formatStat(s.spec, s.sum)},
+				item{"平均值", formatStat(s.spec, s.mean)},
+				item{"中位数", formatStat(s.spec, s.median)},
+				item{"P95", formatStat(s.spec, s.p95)},
+				item{"标准差", formatStat(s.spec, s.stddev)})
+		}
+	} else if s.nonEmpty > 0 {
+		items = append(items,
+			item{"最短长度", fmt.Sprint(s.minLen)},
+			item{"最长长度", fmt.Sprint(s.maxLen)})
+	}
+
+	nameWidth, valueWidth := 0, 0
+	for _, it := range items {
+		nameWidth = max(nameWidth, DisplayWidth(it.name))
+		valueWidth = max(valueWidth, DisplayWidth(it.value))
+	}
+	valueWidth = min(valueWidth, max(m.Width-nameWidth-6, 8))
+	cellWidth := nameWidth + valueWidth + 6
+	perLine := max(min((m.Width-2)/cellWidth, 3), 1)
+	var lines []string
+	for i := 0; i < len(items); i += perLine {
+		var b strings.Builder
+		for _, it := range items[i:min(i+perLine, len(items))] {
+			b.WriteString("  " + m.Theme.FieldName.Render(fitCell(it.name, nameWidth, lipgloss.Left)) + "  " +
+				fitCell(it.value, valueWidth, lipgloss.Left) + "  ")
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	// 最常见的值，按剩余高度显示
+	if len(s.top) > 0 && len(lines)+2 < height {
+		lines = append(lines, "", m.Theme.Title.Render(fmt.Sprintf("最常见的 %d 个值", len(s.top))))
+		countWidth := 0
+		for _, vc := range s.top {
+			countWidth = max(countWidth, DisplayWidth(percent(vc.count)))
+		}
+		valueWidth := max(m.Width-countWidth-6, 8)
+		for _, vc := range s.top {
+			if len(lines) >= height {
+				break
+			}
+			lines = append(lines, "  "+fitCell(s.spec.FormatCell(vc.value), valueWidth, lipgloss.Left)+"  "+
+				fitCell(percent(vc.count), countWidth, lipgloss.Right))
+		}
+	}
+	return lines
+}
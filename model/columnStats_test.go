@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestComputeColumnStats 按列类型统计，空值和无法解析的值不参与数值计算
+func TestComputeColumnStats(t *testing.T) {
+	rows := []table.Row{{"3"}, {"1"}, {""}, {"abc"}, {"2"}, {"4"}, {"1"}}
+	cases := []struct {
+		name                     string
+		spec                     ColumnSpec
+		nonEmpty, distinct, bad  int
+		min, max                 string
+		sum, mean, median        float64
+		minLen, maxLen, topCount int
+	}{
+		{
+			name: "整数列", spec: ColumnSpec{Type: ColumnInt},
+			nonEmpty: 6, distinct: 5, bad: 1, min: "1", max: "4",
+			sum: 11, mean: 2.2, median: 2, minLen: 1, maxLen: 3,
+		},
+		{
+			name: "字符串列", spec: ColumnSpec{Type: ColumnString},
+			nonEmpty: 6, distinct: 5, min: "1", max: "abc",
+			minLen: 1, maxLen: 3, topCount: 2,
+		},
+	}
+	for _, c := range cases {
+		s, err := computeColumnStats(context.Background(), rows, 0, c.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.count != len(rows) || s.nonEmpty != c.nonEmpty || s.distinct != c.distinct || s.invalid != c.bad {
+			t.Errorf("%s: 行数 %d 非空 %d 不同值 %d 无法解析 %d", c.name, s.count, s.nonEmpty, s.distinct, s.invalid)
+		}
+		if s.min != c.min || s.max != c.max {
+			t.Errorf("%s: 范围 %q..%q，期望 %q..%q", c.name, s.min, s.max, c.min, c.max)
+		}
+		if s.sum != c.sum || s.mean != c.mean || s.median != c.median {
+			t.Errorf("%s: 总和 %v 平均 %v 中位数 %v", c.name, s.sum, s.mean, s.median)
+		}
+		if s.minLen != c.minLen || s.maxLen != c.maxLen {
+			t.Errorf("%s: 长度 %d..%d", c.name, s.minLen, s.maxLen)
+		}
+		if c.topCount > 0 && (len(s.top) == 0 || s.top[0] != valueCount{"1", c.topCount}) {
+			t.Errorf("%s: 最常见的值 %v", c.name, s.top)
+		}
+	}
+}
+
+// TestPercentile 在相邻两个值之间线性插值
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4}
+	cases := []struct{ p, want float64 }{{0, 1}, {0.5, 2.5}, {0.95, 3.85}, {1, 4}}
+	for _, c := range cases {
+		if got := percentile(sorted, c.p); got < c.want-1e-9 || got > c.want+1e-9 {
+			t.Errorf("percentile(%v) = %v，期望 %v", c.p, got, c.want)
+		}
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("空切片的百分位数为 %v", got)
+	}
+}
+
+// TestOpenStats 没有数据时只提示而不统计占位行
+func TestOpenStats(t *testing.T) {
+	cases := []struct {
+		name string
+		rows [][]string
+		open bool
+	}{
+		{"有数据", [][]string{{"1"}, {"2"}}, true},
+		{"没有数据", nil, false},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, TableData{Headers: []string{"数量"}, Rows: c.rows})
+		m.OpenStats()
+		if (m.stats != nil) != c.open {
+			t.Errorf("%s: 面板打开为 %v，期望 %v", c.name, m.stats != nil, c.open)
+		}
+		if !c.open && m.StatusMsg == "" {
+			t.Errorf("%s: 没有提示信息", c.name)
+		}
+		if c.open && (m.stats.result == nil || m.stats.result.count != len(c.rows)) {
+			t.Errorf("%s: 统计结果 %+v", c.name, m.stats.result)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultPageSize 每次从数据源读取的默认行数
+const DefaultPageSize = 200
+
+// DataSource 表格数据源，表格按需分页读取数据行
+type DataSource interface {
+	// Schema 返回列定义
+	Schema() []ColumnSpec
+	// Len 返回总行数，未知时返回 -1
+	Len() int
+	// Fetch 读取从 offset 开始的最多 limit 行，返回的行数少于 limit 表示已读到末尾
+	Fetch(ctx context.Context, offset, limit int) ([][]string, error)
+}
+
+// SortableSource 支持服务端排序的数据源，排序变化时表格调用 Sort 后从头重新读取
+type SortableSource interface {
+	DataSource
+	// Sort 设置排序，keys 为空表示恢复默认顺序
+	Sort(keys []SortKey) error
+}
+
+// FilterableSource 支持服务端筛选的数据源，筛选条件变化时表格调用 Filter 后从头重新读取
+type FilterableSource interface {
+	DataSource
+	// Filter 设置筛选条件，多个条件之间为“且”的关系；可通过 String 取得表达式文本
+	Filter(filters []FilterExpr) error
+}
+
+// KeyedSource 声明了主键列的数据源，表格在排序、筛选、刷新和重新读取前后按主键识别同一条记录
+type KeyedSource interface {
+	DataSource
+	// KeyColumns 返回主键列名
+	KeyColumns() []string
+}
+
+// Schema 实现 DataSource 接口，未提供列定义时根据数据推断
+func (d TableData) Schema() []ColumnSpec {
+	return resolveColumnSpecs(d)
+}
+
+// Len 实现 DataSource 接口
+func (d TableData) Len() int {
+	return len(d.Rows)
+}
+
+// KeyColumns 实现 KeyedSource 接口
+func (d TableData) KeyColumns() []string {
+	return d.Keys
+}
+
+// Fetch 实现 DataSource 接口
+func (d TableData) Fetch(ctx context.Context, offset, limit int) ([][]string, error) {
+	if offset >= len(d.Rows) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(d.Rows) {
+		end = len(d.Rows)
+	}
+	return d.Rows[offset:end], nil
+}
+
+// sourceReloadMsg 请求从头读取数据源
+type sourceReloadMsg struct{}
+
+// rowsLoadedMsg 一页数据读取完成
+type rowsLoadedMsg struct {
+	gen     int           // 发起读取时的数据源代数
+	rows    [][]string    // 读取到的行
+	limit   int           // 请求的行数
+	err     error         // 读取错误
+	elapsed time.Duration // 读取耗时
+}
+
+// ShowSource 显示数据源中的数据，滚动到已加载数据的末尾时自动读取下一页
+func ShowSource(src DataSource, opts ...Option) error {
+	cfg := newConfig(opts)
+	m, err := newSourceModel(src, cfg).configure(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = runProgram(m, cfg.programOptions()...)
+	return err
+}
+
+// NewTableModelFromSource 根据数据源构建表格模型，第一页数据在 Init 返回的命令中读取
+func NewTableModelFromSource(src DataSource, opts ...Option) (TableModel, error) {
+	cfg := newConfig(opts)
+	return newSourceModel(src, cfg).configure(cfg)
+}
+
+// newSourceModel 按数据源的列定义构建尚未载入数据的表格模型
+func newSourceModel(src DataSource, cfg config) TableModel {
+	specs := src.Schema()
+	widths := CalculateColumnWidths(specs, nil, cfg.minWidth, cfg.maxWidth)
+	tableColumns := make([]table.Column, len(specs))
+	for i, spec := range specs {
+		tableColumns[i] = table.Column{Title: spec.Name, Width: widths[i]}
+	}
+
+	m := NewTableModel()
+	m.Title = cfg.title
+	m.Source = src
+	m.PageSize = cfg.pageSize
+	m.TableColumns = tableColumns
+	m.Columns = specs
+	m.minColWidth = cfg.minWidth
+	m.maxColWidth = cfg.maxWidth
+	m.emptyText = cfg.emptyText
+	if src, ok := src.(KeyedSource); ok {
+		m.keyNames = src.KeyColumns()
+	}
+	m.Cursor.Focus()
+	return m
+}
+
+// configure 应用配置并按默认尺寸计算布局
+func (m TableModel) configure(cfg config) (TableModel, error) {
+	if err := m.resolveKeyColumns(); err != nil {
+		return m, err
+	}
+
+	// 应用键位、配色、初始筛选和排序等配置
+	if err := m.applyConfig(cfg); err != nil {
+		return m, err
+	}
+
+	// 按默认尺寸计算可见列数并更新视图
+	m.SetSize(80, 24)
+	m.SortRows()
+
+	return m, nil
+}
+
+// appendRows 追加一页数据行，按新数据加宽列，并保持光标停留在原来的行上
+func (m *TableModel) appendRows(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	// 去掉“无数据”占位行
+	if m.RowCount == 0 {
+		m.AllRows = nil
+	}
+	for _, row := range rows {
+		m.AllRows = append(m.AllRows, table.Row(row))
+	}
+	m.RowCount = len(m.AllRows)
+
+	widths := CalculateColumnWidths(m.Columns, rows, m.minColWidth, m.maxColWidth)
+	for i, w := range widths {
+		if i < len(m.TableColumns) && w > m.TableColumns[i].Width {
+			m.TableColumns[i].Width = w
+		}
+	}
+
+	// 追加数据不打断正在执行的后台排序/筛选
+	m.appending = true
+	m.ApplyFilter()
+	m.appending = false
+}
+
+// finishSource 标记数据源已读完，没有任何数据时显示占位行
+func (m *TableModel) finishSource() {
+	m.sourceDone = true
+	if m.RowCount > 0 {
+		return
+	}
+	emptyRow := make(table.Row, len(m.TableColumns))
+	for i := range emptyRow {
+		emptyRow[i] = m.emptyText
+	}
+	m.AllRows = []table.Row{emptyRow}
+	m.ApplyFilter()
+}
+
+// loadMore 读取下一页数据，已在读取或已读完时返回 nil
+func (m *TableModel) loadMore() tea.Cmd {
+	if m.Source == nil || m.sourceDone || m.Loading {
+		return nil
+	}
+	m.Loading = true
+	m.LoadErr = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.sourceCancel = cancel
+	src, gen, offset, limit := m.Source, m.sourceGen, m.RowCount, m.pageSize()
+	return func() tea.Msg {
+		start := time.Now()
+		rows, err := src.Fetch(ctx, offset, limit)
+		return rowsLoadedMsg{gen: gen, rows: rows, limit: limit, err: err, elapsed: time.Since(start)}
+	}
+}
+
+// CancelFetch 取消正在执行的读取，用于退出或从头重新读取之前
+func (m *TableModel) CancelFetch() {
+	if m.sourceCancel != nil {
+		m.sourceCancel()
+		m.sourceCancel = nil
+	}
+}
+
+// maybeLoadMore 光标接近已加载数据的末尾时读取下一页，第一页由 Init 发起
+func (m *TableModel) maybeLoadMore() tea.Cmd {
+	if m.Source == nil || m.sourceGen == 0 || m.sourceDone || m.Loading || m.LoadErr != nil {
+		return nil
+	}
+	if m.Cursor.Index() < len(m.OriginalRows)-m.bodyHeight() {
+		return nil
+	}
+	return m.loadMore()
+}
+
+// handleRowsLoaded 处理读取到的一页数据，丢弃数据源重新加载之前发起的读取
+func (m *TableModel) handleRowsLoaded(msg rowsLoadedMsg) {
+	if msg.gen != m.sourceGen {
+		return
+	}
+	m.CancelFetch()
+	m.Loading = false
+	if msg.err != nil {
+		m.LoadErr = msg.err
+		m.StatusMsg = fmt.Sprintf("加载失败: %v（按 %s 重试）", msg.err, m.Keys.Retry.Help().Key)
+		return
+	}
+	if m.RowCount == 0 {
+		m.QueryDuration = msg.elapsed
+	}
+	from := m.RowCount
+	m.appendRows(msg.rows)
+	if n := m.Source.Len(); len(msg.rows) < msg.limit || (n >= 0 && m.RowCount >= n) {
+		m.finishSource()
+	}
+
+	// 重新读取后按主键恢复选择，光标只在第一页中查找，避免之后跳动
+	if m.pendingMarks != nil {
+		m.restoreMarks(*m.pendingMarks, from)
+		m.pendingMarks.cursor = ""
+		if m.sourceDone {
+			m.pendingMarks = nil
+		}
+	}
+}
+
+// reloadSource 将排序和筛选条件下推给数据源后从头读取
+func (m *TableModel) reloadSource() tea.Cmd {
+	// 先停止按原来的条件进行的读取，再修改数据源的排序和筛选
+	m.CancelFetch()
+	if src, ok := m.Source.(SortableSource); ok {
+		if err := src.Sort(m.SortKeys); err != nil {
+			m.StatusMsg = fmt.Sprintf("排序失败: %v", err)
+		}
+	}
+	if src, ok := m.Source.(FilterableSource); ok {
+		if err := src.Filter(m.Filters); err != nil {
+			m.StatusMsg = fmt.Sprintf("筛选失败: %v", err)
+		}
+	}
+
+	// 没有主键时无法在新的结果中找到原来的记录
+	m.pendingMarks = nil
+	if len(m.keyColumns) > 0 {
+		marks := m.saveMarks()
+		m.pendingMarks = &marks
+	}
+
+	m.sourceGen++
+	m.sourceDone = false
+	m.Loading = false
+	m.RowCount = 0
+	m.AllRows = nil
+	m.Selected = nil
+	m.Cursor.Set(0)
+	m.ApplyFilter()
+	return m.loadMore()
+}
+
+// syncSource 排序或筛选条件变化且数据源支持下推时重新读取
+func (m *TableModel) syncSource(before TableModel) tea.Cmd {
+	if m.Source == nil {
+		return nil
+	}
+	if (m.sortPushedDown() && !sortKeysEqual(before.SortKeys, m.SortKeys)) ||
+		(m.filterPushedDown() && filtersSignature(before.Filters) != filtersSignature(m.Filters)) {
+		return m.reloadSource()
+	}
+	return nil
+}
+
+// sortPushedDown 排序是否由数据源完成
+func (m TableModel) sortPushedDown() bool {
+	_, ok := m.Source.(SortableSource)
+	return ok
+}
+
+// filterPushedDown 筛选是否由数据源完成
+func (m TableModel) filterPushedDown() bool {
+	_, ok := m.Source.(FilterableSource)
+	return ok
+}
+
+// pageSize 返回每页读取的行数
+func (m TableModel) pageSize() int {
+	if m.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return m.PageSize
+}
+
+// loadStatus 返回数据加载进度，数据源已读完时返回空字符串
+func (m TableModel) loadStatus() string {
+	if m.Source == nil || m.sourceDone {
+		return ""
+	}
+	status := fmt.Sprintf("已加载 %d", m.RowCount)
+	if n := m.Source.Len(); n >= 0 {
+		status += fmt.Sprintf("/%d", n)
+	}
+	if m.Loading {
+		status += " 加载中…"
+	}
+	return status
+}
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countingSource 记录读取次数的数据源
+type countingSource struct {
+	data    TableData
+	known   bool  // 是否报告总行数
+	offsets []int // 每次读取的起始位置
+}
+
+func (s *countingSource) Schema() []ColumnSpec { return s.data.Schema() }
+
+func (s *countingSource) Len() int {
+	if !s.known {
+		return -1
+	}
+	return s.data.Len()
+}
+
+func (s *countingSource) Fetch(ctx context.Context, offset, limit int) ([][]string, error) {
+	s.offsets = append(s.offsets, offset)
+	return s.data.Fetch(ctx, offset, limit)
+}
+
+// pushdownSource 支持下推排序和筛选的数据源，只记录条件而不改变返回的数据，
+// 以便确认表格没有在本地再次排序或筛选
+type pushdownSource struct {
+	*countingSource
+	sorts   [][]SortKey
+	filters []string
+}
+
+func (s *pushdownSource) Sort(keys []SortKey) error {
+	s.sorts = append(s.sorts, append([]SortKey(nil), keys...))
+	return nil
+}
+
+func (s *pushdownSource) Filter(filters []FilterExpr) error {
+	s.filters = append(s.filters, filtersSignature(filters))
+	return nil
+}
+
+// sourceUpdate 处理消息，并同步执行由此发起的全部读取
+func sourceUpdate(t *testing.T, m TableModel, msg tea.Msg) TableModel {
+	t.Helper()
+	queue := []tea.Msg{msg}
+	for i := 0; len(queue) > 0; i++ {
+		if i > 100 {
+			t.Fatal("读取没有结束")
+		}
+		tm, cmd := m.Update(queue[0])
+		m = tm.(TableModel)
+		finishViewJob(t, &m)
+		queue = queue[1:]
+		for _, next := range collectMsgs(cmd) {
+			switch next.(type) {
+			case rowsLoadedMsg, sourceReloadMsg:
+				queue = append(queue, next)
+			}
+		}
+	}
+	return m
+}
+
+// newSourceTestModel 按测试尺寸构建数据源表格并读取首屏数据
+func newSourceTestModel(t *testing.T, src DataSource, opts ...Option) TableModel {
+	t.Helper()
+	m, err := NewTableModelFromSource(src, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetSize(120, 40)
+	return sourceUpdate(t, m, sourceReloadMsg{})
+}
+
+// numberedRows 返回 n 行编号数据
+func numberedRows(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i + 1)}
+	}
+	return rows
+}
+
+// TestSourcePaging 分页读取：不知道总行数时读到不满一页为止，首屏填满后不再预读
+func TestSourcePaging(t *testing.T) {
+	cases := []struct {
+		name        string
+		rows        int
+		pageSize    int
+		known       bool
+		wantOffsets []int
+		wantDone    bool
+	}{
+		{"已知总行数", 8, 4, true, []int{0, 4}, true},
+		{"未知总行数", 8, 4, false, []int{0, 4, 8}, true},
+		{"不满一页", 3, 4, false, []int{0}, true},
+		{"首屏填满后停止", 100, 20, true, []int{0, 20}, false},
+	}
+	for _, c := range cases {
+		src := &countingSource{data: TableData{Headers: []string{"ID"}, Rows: numberedRows(c.rows)}, known: c.known}
+		m := newSourceTestModel(t, src, WithPageSize(c.pageSize))
+		if !slices.Equal(src.offsets, c.wantOffsets) {
+			t.Errorf("%s: 读取位置 %v，期望 %v", c.name, src.offsets, c.wantOffsets)
+		}
+		if m.sourceDone != c.wantDone {
+			t.Errorf("%s: 读完为 %v，期望 %v", c.name, m.sourceDone, c.wantDone)
+		}
+		if want := min(c.rows, len(c.wantOffsets)*c.pageSize); m.RowCount != want {
+			t.Errorf("%s: 已加载 %d 行，期望 %d", c.name, m.RowCount, want)
+		}
+	}
+}
+
+// TestSourcePushdown 数据源支持时排序和筛选下推并从头读取，否则在本地计算
+func TestSourcePushdown(t *testing.T) {
+	filterX := []string{"right", "f", "x", "enter"}
+	cases := []struct {
+		name     string
+		pushdown bool
+		keys     []string
+		want     []string // 显示的第一列
+		reloads  int      // 从头读取的次数，含首次读取
+	}{
+		{"本地排序", false, []string{"s", "s"}, []string{"3", "2", "1"}, 1},
+		{"下推排序", true, []string{"s", "s"}, []string{"1", "2", "3"}, 3},
+		{"本地筛选", false, filterX, []string{"1", "3"}, 1},
+		{"下推筛选", true, filterX, []string{"1", "2", "3"}, 2},
+	}
+	for _, c := range cases {
+		counting := &countingSource{data: TableData{
+			Headers: []string{"ID", "组"},
+			Rows:    [][]string{{"1", "x"}, {"2", "y"}, {"3", "x"}},
+		}}
+		var src DataSource = counting
+		pushdown := &pushdownSource{countingSource: counting}
+		if c.pushdown {
+			src = pushdown
+		}
+		m := newSourceTestModel(t, src)
+		for _, k := range c.keys {
+			m = sourceUpdate(t, m, keyMsg(k))
+		}
+
+		var got []string
+		for _, row := range m.OriginalRows {
+			got = append(got, row[0])
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: 显示 %v，期望 %v", c.name, got, c.want)
+		}
+		if !slices.Equal(counting.offsets, make([]int, c.reloads)) {
+			t.Errorf("%s: 读取位置 %v，期望从头读取 %d 次", c.name, counting.offsets, c.reloads)
+		}
+		if !c.pushdown {
+			continue
+		}
+		wantSort := []SortKey{{Column: 0, Desc: true}}
+		wantFilter := ""
+		if c.keys[0] != "s" {
+			wantSort = nil
+			wantFilter = mustParseFilter(t, "x", counting.data, 1).String()
+		}
+		if last := pushdown.sorts[len(pushdown.sorts)-1]; !sortKeysEqual(last, wantSort) {
+			t.Errorf("%s: 下推排序 %v，期望 %v", c.name, last, wantSort)
+		}
+		if last := pushdown.filters[len(pushdown.filters)-1]; last != wantFilter {
+			t.Errorf("%s: 下推筛选 %q，期望 %q", c.name, last, wantFilter)
+		}
+	}
+}
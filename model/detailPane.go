@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 预览栏的最小宽度
+const minPaneWidth = 24
+
+// 终端太窄而隐藏预览栏时的状态消息
+const paneHiddenStatus = "终端宽度不足，已隐藏预览栏"
+
+// 预览栏样式
+var paneStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("63")).
+	Padding(0, 1)
+
+// PaneRenderer 自定义预览栏的内容，参数为光标所在行和全部列，超出预览栏的部分会被截掉
+type PaneRenderer func(row table.Row, cols []table.Column) string
+
+// TogglePane 显示或隐藏预览栏，并按剩余宽度重新计算可见列
+func (m *TableModel) TogglePane() {
+	m.ShowPane = !m.ShowPane
+	m.SetSize(m.viewWidth, m.viewHeight)
+}
+
+// paneWidth 返回预览栏占用的总宽度（含边框），未显示或放不下时为 0
+func (m TableModel) paneWidth() int {
+	if !m.paneVisible() {
+		return 0
+	}
+	width := m.PaneWidth
+	if width <= 0 {
+		width = m.viewWidth * 2 / 5
+	}
+	return max(min(width, m.paneSpace()), minPaneWidth)
+}
+
+// paneSpace 返回至少给表格留出一列的宽度后，预览栏最多可以占用的宽度
+func (m TableModel) paneSpace() int {
+	h, _ := m.Theme.Base.GetFrameSize()
+	return m.viewWidth - h - MinColumnWidth - 2
+}
+
+// paneVisible 预览栏是否显示，终端宽度放不下最小宽度的预览栏时隐藏
+func (m TableModel) paneVisible() bool {
+	return m.ShowPane && m.paneSpace() >= minPaneWidth
+}
+
+// paneContentWidth 返回预览栏内容区的宽度
+func (m TableModel) paneContentWidth() int {
+	return max(m.paneWidth()-m.Theme.Pane.GetHorizontalFrameSize(), 1)
+}
+
+// paneContentHeight 返回预览栏内容区的高度，预览栏与表格等高
+func (m TableModel) paneContentHeight() int {
+	return max(m.Height-m.Theme.Pane.GetVerticalFrameSize(), 1)
+}
+
+// detailInPane 记录详情是否显示在预览栏中，使用自定义内容时仍全屏显示
+func (m TableModel) detailInPane() bool {
+	return m.paneVisible() && m.PaneRenderer == nil
+}
+
+// renderPane 渲染预览栏，默认显示光标所在记录的详情
+func (m TableModel) renderPane() string {
+	width, height := m.paneContentWidth(), m.paneContentHeight()
+
+	var content string
+	r := m.Cursor.Index()
+	switch {
+	case m.RowCount == 0 || r >= len(m.OriginalRows):
+		content = m.Theme.Info.UnsetMarginLeft().Render(m.emptyText)
+	case m.PaneRenderer != nil:
+		content = m.PaneRenderer(m.OriginalRows[r], m.TableColumns)
+	default:
+		header := m.detailStatus()
+		if m.detail != nil && m.detail.searching {
+			header = m.detail.input.View()
+		}
+		content = m.Theme.Title.UnsetMarginLeft().Render(TruncateWidth(header, width)) + "\n" + m.renderDetail(width, height-1)
+	}
+
+	// 内容超出预览栏时截断
+	lines := strings.Split(content, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		lines[i] = TruncateWidth(line, width)
+	}
+	return m.Theme.Pane.
+		Width(width + m.Theme.Pane.GetHorizontalPadding()).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+}
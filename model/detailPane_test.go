@@ -0,0 +1,80 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestPaneWidth 预览栏宽度限制在最小宽度和给表格留出一列后的剩余宽度之间，放不下时隐藏
+func TestPaneWidth(t *testing.T) {
+	cases := []struct {
+		name       string
+		show       bool
+		paneWidth  int
+		sizes      []int // 依次设置的终端宽度
+		want       int
+		wantStatus string
+	}{
+		{"未显示", false, 0, []int{120}, 0, ""},
+		{"默认宽度", true, 0, []int{120}, 48, ""},
+		{"指定宽度", true, 30, []int{120}, 30, ""},
+		{"不超过剩余宽度", true, 200, []int{120}, 120 - MinColumnWidth - 2, ""},
+		{"不小于最小宽度", true, 10, []int{120}, minPaneWidth, ""},
+		{"窄终端使用最小宽度", true, 0, []int{40}, minPaneWidth, ""},
+		{"放不下时隐藏", true, 0, []int{30}, 0, paneHiddenStatus},
+		{"放大后重新显示", true, 0, []int{30, 120}, 48, ""},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, filterData)
+		m.ShowPane, m.PaneWidth = c.show, c.paneWidth
+		for _, w := range c.sizes {
+			m.SetSize(w, 40)
+		}
+		width := c.sizes[len(c.sizes)-1]
+		if got := m.paneWidth(); got != c.want || m.Width != width-got {
+			t.Errorf("%s: 预览栏 %d、表格 %d，期望 %d、%d", c.name, got, m.Width, c.want, width-c.want)
+		}
+		if m.StatusMsg != c.wantStatus {
+			t.Errorf("%s: 状态 %q，期望 %q", c.name, m.StatusMsg, c.wantStatus)
+		}
+	}
+}
+
+// TestPaneView 预览栏默认显示光标所在记录的详情，可替换为自定义内容，不超出终端宽度
+func TestPaneView(t *testing.T) {
+	custom := func(row table.Row, cols []table.Column) string {
+		return "自定义 " + row[0] + strings.Repeat("宽", 100)
+	}
+	cases := []struct {
+		name       string
+		renderer   PaneRenderer
+		keys       []string
+		want       string
+		wantInPane bool
+	}{
+		{"记录详情", nil, []string{"down"}, "记录 2/", true},
+		{"自定义内容", custom, []string{"down"}, "自定义 " + filterData.Rows[1][0], false},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, filterData, WithDetailPane(0))
+		m.PaneRenderer = c.renderer
+		m = press(t, m, c.keys...)
+		view := m.View()
+		if !strings.Contains(view, c.want) {
+			t.Errorf("%s: 预览栏没有显示 %q:\n%s", c.name, c.want, view)
+		}
+		// 最后的帮助行不参与分栏
+		lines := strings.Split(view, "\n")
+		for i, line := range lines[:len(lines)-1] {
+			if w := lipgloss.Width(line); w > 120 {
+				t.Errorf("%s: 第 %d 行宽度 %d 超出终端", c.name, i, w)
+			}
+		}
+		if m.detailInPane() != c.wantInPane {
+			t.Errorf("%s: 详情在预览栏中为 %v", c.name, m.detailInPane())
+		}
+	}
+}
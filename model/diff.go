@@ -0,0 +1,342 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 差异模式下第一列的列名和各种变更的标记，未变化的行为空
+const (
+	DiffColumn  = "变更"
+	DiffAdded   = "新增"
+	DiffRemoved = "删除"
+	DiffChanged = "修改"
+)
+
+// 差异模式的样式
+var (
+	diffAddedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+
+	diffRemovedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("203")).
+				Strikethrough(true)
+
+	diffChangedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("16")).
+				Background(lipgloss.Color("180"))
+)
+
+// tableDiff 两次查询结果之间的差异
+type tableDiff struct {
+	old     map[cellRef]string // 修改过的单元格在修改前的值，Row 为 AllRows 中的索引
+	added   int
+	removed int
+	changed int
+}
+
+// diffOnlyFilter 只保留有变更的行，由 ShowDiffOnly 控制，不加入 Filters
+type diffOnlyFilter struct{}
+
+// Match 实现 FilterExpr 接口
+func (diffOnlyFilter) Match(row table.Row) bool {
+	return cellAt(row, 0) != ""
+}
+
+// String 实现 FilterExpr 接口
+func (diffOnlyFilter) String() string {
+	return "仅变更"
+}
+
+// ShowDiff 对比同一查询的两次结果，新增的行显示为绿色，删除的行显示为红色并划线，修改过的单元格高亮显示
+// 按 cur（未声明时按 old）的主键列识别同一条记录，都未声明时按行的位置对应
+func ShowDiff(old, cur TableData, opts ...Option) error {
+	cfg := newConfig(opts)
+	m, err := newDiffModel(old, cur, cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = runProgram(m, cfg.programOptions()...)
+	return err
+}
+
+// NewTableModelFromDiff 根据两次查询结果构建差异模式的表格模型，不启动程序
+func NewTableModelFromDiff(old, cur TableData, opts ...Option) (TableModel, error) {
+	return newDiffModel(old, cur, newConfig(opts))
+}
+
+// newDiffModel 合并两次结果并标记每一行的变更，删除的行放在它在旧结果中前一条仍存在的记录之后
+func newDiffModel(old, cur TableData, cfg config) (TableModel, error) {
+	specs := cur.Schema()
+	if !sameColumns(old.Schema(), specs) {
+		return TableModel{}, fmt.Errorf("两次结果的列不一致，无法对比")
+	}
+	keys := cur.Keys
+	if len(keys) == 0 {
+		keys = old.Keys
+	}
+	keyColumns, err := columnIndexes(keys, specs)
+	if err != nil {
+		return TableModel{}, fmt.Errorf("主键%w", err)
+	}
+
+	// 找出旧结果中每条记录在新结果中的位置
+	curIndex := make(map[string]int, len(cur.Rows))
+	for j, row := range cur.Rows {
+		curIndex[recordKey(row, keyColumns, j)] = j
+	}
+	oldOf := make([]int, len(cur.Rows))
+	for j := range oldOf {
+		oldOf[j] = -1
+	}
+	removedAfter := make(map[int][]int)
+	anchor := -1
+	for i, row := range old.Rows {
+		if j, ok := curIndex[recordKey(row, keyColumns, i)]; ok {
+			oldOf[j] = i
+			anchor = j
+			continue
+		}
+		removedAfter[anchor] = append(removedAfter[anchor], i)
+	}
+
+	headers := make([]string, 0, len(specs)+1)
+	headers = append(headers, DiffColumn)
+	for _, spec := range specs {
+		headers = append(headers, spec.Name)
+	}
+	data := TableData{
+		Title:    cur.Title,
+		Headers:  headers,
+		Columns:  append([]ColumnSpec{{Name: DiffColumn}}, specs...),
+		Keys:     keys,
+		Metadata: cur.Metadata,
+	}
+	diff := &tableDiff{old: make(map[cellRef]string)}
+	emit := func(status string, row []string) {
+		data.Rows = append(data.Rows, append([]string{status}, row...))
+	}
+	emitRemoved := func(anchor int) {
+		for _, i := range removedAfter[anchor] {
+			emit(DiffRemoved, old.Rows[i])
+			diff.removed++
+		}
+	}
+
+	emitRemoved(-1)
+	for j, row := range cur.Rows {
+		status := ""
+		if i := oldOf[j]; i < 0 {
+			status = DiffAdded
+			diff.added++
+		} else {
+			for c := range specs {
+				before, after := cellAt(old.Rows[i], c), cellAt(row, c)
+				if before != after {
+					diff.old[cellRef{Row: len(data.Rows), Col: c + 1}] = before
+					status = DiffChanged
+				}
+			}
+			if status == DiffChanged {
+				diff.changed++
+			}
+		}
+		emit(status, row)
+		emitRemoved(j)
+	}
+
+	m, err := newTableModel(data, cfg)
+	if err != nil {
+		return m, err
+	}
+	m.diff = diff
+	return m, nil
+}
+
+// diffCellStyle 按行的变更类型和单元格是否被修改调整样式
+func (m TableModel) diffCellStyle(style lipgloss.Style, r, col int) lipgloss.Style {
+	switch cellAt(m.OriginalRows[r], 0) {
+	case DiffAdded:
+		return overlayStyle(style, m.Theme.DiffAdded)
+	case DiffRemoved:
+		return overlayStyle(style, m.Theme.DiffRemoved)
+	}
+	if _, ok := m.oldValue(r, col); ok {
+		return overlayStyle(style, m.Theme.DiffChanged)
+	}
+	return style
+}
+
+// oldValue 返回 OriginalRows 中单元格修改前的值
+func (m TableModel) oldValue(r, col int) (string, bool) {
+	if m.diff == nil || r >= len(m.RowIndex) {
+		return "", false
+	}
+	value, ok := m.diff.old[cellRef{Row: m.RowIndex[r], Col: col}]
+	return value, ok
+}
+
+// ToggleDiffOnly 切换是否只显示有变更的行，与筛选条件叠加
+func (m *TableModel) ToggleDiffOnly() {
+	m.ShowDiffOnly = !m.ShowDiffOnly
+	m.ApplyFilter()
+}
+
+// diffStatus 返回差异统计
+func (m TableModel) diffStatus() string {
+	if m.diff == nil {
+		return ""
+	}
+	status := fmt.Sprintf("差异: +%d -%d ~%d", m.diff.added, m.diff.removed, m.diff.changed)
+	if m.ShowDiffOnly {
+		status += "（仅变更）"
+	}
+	return status
+}
+
+// diffDetailView 显示光标所在记录每一列修改前后的值，高度与表格相同
+func (m TableModel) diffDetailView() string {
+	r := m.Cursor.Index()
+	if m.RowCount == 0 || r >= len(m.OriginalRows) {
+		return ""
+	}
+	row := m.OriginalRows[r]
+	status := cellAt(row, 0)
+
+	nameWidth := DisplayWidth("列")
+	for _, col := range m.TableColumns[1:] {
+		nameWidth = max(nameWidth, DisplayWidth(col.Title))
+	}
+	valueWidth := max((m.Width-nameWidth-6)/2, 8)
+
+	title := "未变化"
+	if status != "" {
+		title = status
+	}
+	lines := []string{
+		m.Theme.Title.Render(fmt.Sprintf("第 %d 行: %s（按任意键关闭）", r+1, title)),
+		m.Theme.Table.Header.Render(fitCell("列", nameWidth, lipgloss.Left) + "  " +
+			fitCell("修改前", valueWidth, lipgloss.Left) + "  " + fitCell("修改后", valueWidth, lipgloss.Left)),
+	}
+	for i, col := range m.TableColumns[1:] {
+		c := i + 1
+		before, after := cellAt(row, c), cellAt(row, c)
+		style := m.Theme.Table.Cell
+		switch status {
+		case DiffAdded:
+			before = ""
+			style = overlayStyle(style, m.Theme.DiffAdded)
+		case DiffRemoved:
+			after = ""
+			style = overlayStyle(style, m.Theme.DiffRemoved)
+		default:
+			if old, ok := m.oldValue(r, c); ok {
+				before = old
+				style = overlayStyle(style, m.Theme.DiffChanged)
+			}
+		}
+		spec := m.columnSpec(c)
+		lines = append(lines, style.Render(fitCell(col.Title, nameWidth, lipgloss.Left)+"  "+
+			fitCell(spec.FormatCell(before), valueWidth, lipgloss.Left)+"  "+
+			fitCell(spec.FormatCell(after), valueWidth, lipgloss.Left)))
+	}
+
+	// 与表格保持相同高度，避免布局跳动
+	height := m.bodyHeight() + 1
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffRecord 差异导出为 JSON 时的一条记录
+type diffRecord struct {
+	Status string          `json:"status"`
+	Row    json.RawMessage `json:"row"`
+	Old    json.RawMessage `json:"old,omitempty"`
+}
+
+// ExportDiff 将指定行的差异导出为 CSV 和 JSON 文件，包含每行的变更类型和修改前的值
+func (m *TableModel) ExportDiff(rows []SelectedRow) (csvPath, jsonPath string, err error) {
+	if err := os.MkdirAll(m.exportDir(), 0755); err != nil {
+		return "", "", fmt.Errorf("创建输出目录失败: %v", err)
+	}
+	csvPath = filepath.Join(m.exportDir(), "差异.csv")
+	jsonPath = filepath.Join(m.exportDir(), "差异.json")
+
+	names := make([]string, 0, len(m.TableColumns))
+	for _, col := range m.TableColumns[1:] {
+		names = append(names, col.Title)
+	}
+
+	// CSV：变更类型、各列的值和修改前的值
+	var b strings.Builder
+	header := append([]string{DiffColumn}, names...)
+	header = append(header, "修改前")
+	b.WriteString(csvLine(header))
+	records := make([]diffRecord, 0, len(rows))
+	for _, row := range rows {
+		values := make([]string, len(names))
+		var oldNames, oldValues, changes []string
+		for i := range names {
+			c := i + 1
+			values[i] = m.columnSpec(c).FormatCell(cellAt(row.Values, c))
+			if old, ok := m.diff.old[cellRef{Row: row.Index, Col: c}]; ok {
+				old = m.columnSpec(c).FormatCell(old)
+				oldNames = append(oldNames, names[i])
+				oldValues = append(oldValues, old)
+				changes = append(changes, names[i]+": "+old)
+			}
+		}
+		status := cellAt(row.Values, 0)
+		line := append([]string{status}, values...)
+		b.WriteString(csvLine(append(line, strings.Join(changes, "; "))))
+
+		record := diffRecord{Status: status, Row: jsonObject(names, values)}
+		if len(oldNames) > 0 {
+			record.Old = jsonObject(oldNames, oldValues)
+		}
+		records = append(records, record)
+	}
+	if err := os.WriteFile(csvPath, []byte(b.String()), 0644); err != nil {
+		return "", "", fmt.Errorf("写入 CSV 失败: %v", err)
+	}
+
+	// JSON：每条记录的变更类型、当前的值和修改前的值
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(jsonPath, content, 0644); err != nil {
+		return "", "", fmt.Errorf("写入 JSON 失败: %v", err)
+	}
+	return csvPath, jsonPath, nil
+}
+
+// jsonObject 按列的顺序生成 JSON 对象
+func jsonObject(names, values []string) json.RawMessage {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		k, _ := json.Marshal(name)
+		v, _ := json.Marshal(values[i])
+		b.Write(k)
+		b.WriteString(":")
+		b.Write(v)
+	}
+	b.WriteString("}")
+	return json.RawMessage(b.String())
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDiffMerge 合并两次结果，标记新增、删除和修改的行，删除的行放在旧结果中前一条仍存在的记录之后
+func TestDiffMerge(t *testing.T) {
+	headers := []string{"ID", "名称"}
+	cases := []struct {
+		name     string
+		keys     []string
+		old, cur [][]string
+		status   []string // 合并后每一行的变更标记
+		ids      []string // 合并后每一行的 ID
+		old1     map[int]string
+	}{
+		{
+			name:   "按主键对应",
+			keys:   []string{"ID"},
+			old:    [][]string{{"1", "a"}, {"2", "b"}, {"3", "c"}},
+			cur:    [][]string{{"3", "c"}, {"1", "A"}, {"4", "d"}},
+			status: []string{"", DiffChanged, DiffRemoved, DiffAdded},
+			ids:    []string{"3", "1", "2", "4"},
+			old1:   map[int]string{1: "a"},
+		},
+		{
+			name:   "删除第一条",
+			keys:   []string{"ID"},
+			old:    [][]string{{"1", "a"}, {"2", "b"}},
+			cur:    [][]string{{"2", "b"}},
+			status: []string{DiffRemoved, ""},
+			ids:    []string{"1", "2"},
+		},
+		{
+			name:   "按位置对应",
+			old:    [][]string{{"1", "a"}, {"2", "b"}},
+			cur:    [][]string{{"1", "a"}, {"2", "x"}, {"3", "c"}},
+			status: []string{"", DiffChanged, DiffAdded},
+			ids:    []string{"1", "2", "3"},
+			old1:   map[int]string{1: "b"},
+		},
+	}
+	for _, c := range cases {
+		m, err := NewTableModelFromDiff(
+			TableData{Headers: headers, Keys: c.keys, Rows: c.old},
+			TableData{Headers: headers, Keys: c.keys, Rows: c.cur})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var status, ids []string
+		for _, row := range m.AllRows {
+			status = append(status, row[0])
+			ids = append(ids, row[1])
+		}
+		if !reflect.DeepEqual(status, c.status) || !reflect.DeepEqual(ids, c.ids) {
+			t.Errorf("%s: 变更 %q ID %q，期望 %q %q", c.name, status, ids, c.status, c.ids)
+		}
+		for r, want := range c.old1 {
+			if got := m.diff.old[cellRef{Row: r, Col: 2}]; got != want {
+				t.Errorf("%s: 第 %d 行修改前为 %q，期望 %q", c.name, r, got, want)
+			}
+		}
+	}
+}
+
+// TestDiffColumnsMismatch 两次结果的列不一致时返回错误
+func TestDiffColumnsMismatch(t *testing.T) {
+	_, err := NewTableModelFromDiff(TableData{Headers: []string{"ID"}}, TableData{Headers: []string{"编号"}})
+	if err == nil {
+		t.Error("列不一致时没有返回错误")
+	}
+}
+
+// TestToggleDiffOnly 只显示有变更的行不加入筛选条件，与筛选条件叠加
+func TestToggleDiffOnly(t *testing.T) {
+	headers := []string{"ID", "城市"}
+	m, err := NewTableModelFromDiff(
+		TableData{Headers: headers, Keys: []string{"ID"}, Rows: [][]string{{"1", "北京"}, {"2", "上海"}, {"3", "北京"}}},
+		TableData{Headers: headers, Keys: []string{"ID"}, Rows: [][]string{{"1", "北京"}, {"2", "深圳"}, {"4", "北京"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name    string
+		keys    []string
+		rows    int
+		filters int
+	}{
+		{"只显示变更", []string{"C"}, 3, 0},
+		{"叠加筛选", []string{"C", "f", "城市 = 北京", "enter"}, 2, 1},
+		{"清除筛选后仍只显示变更", []string{"C", "f", "城市 = 北京", "enter", "r"}, 3, 0},
+		{"再按一次恢复", []string{"C", "C"}, 4, 0},
+	}
+	for _, c := range cases {
+		got := press(t, m, c.keys...)
+		if len(got.OriginalRows) != c.rows || len(got.Filters) != c.filters {
+			t.Errorf("%s: 显示 %d 行、%d 个筛选条件，期望 %d 行、%d 个", c.name, len(got.OriginalRows), len(got.Filters), c.rows, c.filters)
+		}
+		for _, f := range got.Filters {
+			if _, err := ParseFilter(f.String(), got.columnSpecs(), -1); err != nil {
+				t.Errorf("%s: 筛选条件 %s 无法重新解析: %v", c.name, f, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"github.com/charmbracelet/x/ansi"
+)
+
+// 列宽自适应时的上下限（显示宽度）
+const (
+	MinColumnWidth = 10
+	MaxColumnWidth = 40
+)
+
+// DisplayWidth 返回字符串在终端中占用的列数
+// 按字素簇计算宽度，中日韩文字和 emoji 计为 2 列，ANSI 转义序列不计宽度
+func DisplayWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// TruncateWidth 按显示宽度截断字符串，超出时以省略号结尾
+// 截断以字素簇为单位，不会拆开宽字符或组合 emoji
+func TruncateWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, "…")
+}
+
+// CalculateColumnWidths 根据列名和格式化后内容的显示宽度计算每列宽度，并限制在上下限之间
+// 列定义中的 MinWidth/MaxWidth 优先于全局上下限
+func CalculateColumnWidths(specs []ColumnSpec, rows [][]string, minWidth, maxWidth int) []int {
+	widths := make([]int, len(specs))
+
+	// 初始化列宽度
+	for i, spec := range specs {
+		widths[i] = DisplayWidth(spec.Name) + 2
+	}
+
+	// 计算每列的最大宽度
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				break
+			}
+			if w := DisplayWidth(specs[i].FormatCell(cell)) + 2; w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	// 限制列宽范围
+	for i, width := range widths {
+		lower, upper := minWidth, maxWidth
+		if specs[i].MinWidth > 0 {
+			lower = specs[i].MinWidth
+		}
+		if specs[i].MaxWidth > 0 {
+			upper = specs[i].MaxWidth
+		}
+		if width < lower {
+			widths[i] = lower
+		} else if width > upper {
+			widths[i] = upper
+		}
+	}
+
+	return widths
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestDisplayWidth 中日韩文字和 emoji 计为 2 列，ANSI 转义序列不计宽度
+func TestDisplayWidth(t *testing.T) {
+	cases := []struct {
+		s    string
+		want int
+	}{
+		{"abc", 3},
+		{"北京", 4},
+		{"ｱｲｳ", 3},
+		{"😀", 2},
+		{"👨‍👩‍👧", 2},
+		{"\x1b[31m红\x1b[0m", 2},
+		{"", 0},
+	}
+	for _, c := range cases {
+		if got := DisplayWidth(c.s); got != c.want {
+			t.Errorf("DisplayWidth(%q) = %d，期望 %d", c.s, got, c.want)
+		}
+	}
+}
+
+// TestTruncateWidth 按显示宽度截断，不拆开宽字符
+func TestTruncateWidth(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"abcdef", 4, "abc…"},
+		{"abc", 3, "abc"},
+		{"北京上海", 5, "北京…"},
+		{"北京上海", 4, "北…"},
+		{"a😀b", 3, "a…"},
+		{"abc", 0, ""},
+	}
+	for _, c := range cases {
+		got := TruncateWidth(c.s, c.width)
+		if got != c.want {
+			t.Errorf("TruncateWidth(%q, %d) = %q，期望 %q", c.s, c.width, got, c.want)
+		}
+		if DisplayWidth(got) > c.width {
+			t.Errorf("TruncateWidth(%q, %d) 的宽度为 %d", c.s, c.width, DisplayWidth(got))
+		}
+	}
+}
+
+// TestFitCell 补齐到指定宽度，宽字符被截断时也不超出
+func TestFitCell(t *testing.T) {
+	for _, value := range []string{"北京上海深圳", "ab", "😀😀😀😀", ""} {
+		for width := 1; width <= 8; width++ {
+			if got := DisplayWidth(fitCell(value, width, lipgloss.Right)); got != width {
+				t.Errorf("fitCell(%q, %d) 的宽度为 %d", value, width, got)
+			}
+		}
+	}
+}
+
+// TestCalculateColumnWidths 按格式化后内容的显示宽度计算列宽，列定义的上下限优先于全局上下限
+func TestCalculateColumnWidths(t *testing.T) {
+	cases := []struct {
+		name  string
+		specs []ColumnSpec
+		rows  [][]string
+		want  []int
+	}{
+		{"中文内容", []ColumnSpec{{Name: "城市"}}, [][]string{{"乌鲁木齐"}}, []int{10}},
+		{"表头最宽", []ColumnSpec{{Name: "名称"}}, [][]string{{"a"}}, []int{6}},
+		{"全局上限", []ColumnSpec{{Name: "备注"}}, [][]string{{"这是一段很长很长的备注内容"}}, []int{20}},
+		{"列定义上限优先", []ColumnSpec{{Name: "备注", MaxWidth: 12}}, [][]string{{"这是一段很长很长的备注内容"}}, []int{12}},
+		{"列定义下限优先", []ColumnSpec{{Name: "ID", MinWidth: 8}}, [][]string{{"1"}}, []int{8}},
+		{"按格式化结果", []ColumnSpec{{Name: "大小", Type: ColumnBytes, Format: "human"}}, [][]string{{"1048576B"}}, []int{9}},
+	}
+	for _, c := range cases {
+		if got := CalculateColumnWidths(c.specs, c.rows, 4, 20); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: 列宽 %v，期望 %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,580 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// FilterExpr 解析后的筛选表达式
+type FilterExpr interface {
+	Match(row table.Row) bool
+	String() string
+}
+
+// FilterError 筛选表达式解析错误
+type FilterError struct {
+	Input string // 原始表达式
+	Pos   int    // 出错位置（字节偏移）
+	Msg   string // 错误描述
+}
+
+func (e *FilterError) Error() string {
+	pos := e.Pos
+	if pos > len(e.Input) {
+		pos = len(e.Input)
+	}
+	return fmt.Sprintf("第 %d 个字符: %s", utf8.RuneCountInString(e.Input[:pos])+1, e.Msg)
+}
+
+// ParseFilter 解析筛选表达式
+//
+// 支持的语法：
+//
+//	年龄 > 30                     比较：= == != <> > >= < <=
+//	姓名 contains 张              包含（不区分大小写）
+//	职业 ~ "^(工程|设计)"         正则匹配，!~ 为不匹配
+//	城市 in (北京, 上海)          集合匹配，not in 为不在集合中
+//	备注 = ""                     空值：引号包裹的空字符串匹配空白的单元格
+//	not (a and b) or c            布尔组合与括号
+//	北京                          裸值：在 defaultColumn 列中匹配（非字符串列按值相等）
+//
+// 列名包含空格或运算符时用反引号包裹，如 `下单 时间` >= 2024-01-01。
+// 字面量按列类型解析，数字、日期、时长、字节数可直接书写，含空格的文本用引号包裹，
+// 引号内的引号连写两次，如 备注 = "说""明"；反斜杠不做转义，正则表达式可以原样书写。
+func ParseFilter(input string, columns []ColumnSpec, defaultColumn int) (FilterExpr, error) {
+	expr, err := parseFilter(input, columns, defaultColumn)
+	if err != nil {
+		if fe, ok := err.(*FilterError); ok {
+			fe.Input = input
+		}
+		return nil, err
+	}
+	return expr, nil
+}
+
+func parseFilter(input string, columns []ColumnSpec, defaultColumn int) (FilterExpr, error) {
+	tokens, err := lexFilter(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens, columns: columns, defaultColumn: defaultColumn}
+	if p.peek().kind == tokEOF {
+		return nil, &FilterError{Pos: 0, Msg: "表达式为空"}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("多余的内容 %q", tok.text)}
+	}
+	return expr, nil
+}
+
+// ---------- 词法分析 ----------
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokColumn // 反引号包裹的列名
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// isWordRune 判断字符是否可以出现在裸词中
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune("()=!<>~,\"'`", r)
+}
+
+func lexFilter(input string) ([]filterToken, error) {
+	var tokens []filterToken
+	i := 0
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokComma, text: ",", pos: i})
+			i++
+		case r == '"' || r == '\'' || r == '`':
+			text, end, ok := scanQuoted(input, i)
+			if !ok {
+				return nil, &FilterError{Pos: i, Msg: "引号未闭合"}
+			}
+			kind := tokString
+			if r == '`' {
+				kind = tokColumn
+			}
+			tokens = append(tokens, filterToken{kind: kind, text: text, pos: i})
+			i = end
+		case strings.ContainsRune("=!<>~", r):
+			op := string(r)
+			if i+1 < len(input) {
+				two := input[i : i+2]
+				switch two {
+				case "==", "!=", "<>", ">=", "<=", "!~":
+					op = two
+				}
+			}
+			if op == "!" {
+				return nil, &FilterError{Pos: i, Msg: "无效的运算符 \"!\""}
+			}
+			tokens = append(tokens, filterToken{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(input) {
+				r, size := utf8.DecodeRuneInString(input[i:])
+				if !isWordRune(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, filterToken{kind: tokWord, text: input[start:i], pos: start})
+		}
+	}
+	tokens = append(tokens, filterToken{kind: tokEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// scanQuoted 读取从 start 处的引号开始的引用文本，引号内连写两个引号表示引号本身，如 "说""明"
+// 返回去掉引号后的文本和闭合引号之后的位置
+func scanQuoted(input string, start int) (string, int, bool) {
+	quote := input[start]
+	var b strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		if input[i] != quote {
+			b.WriteByte(input[i])
+			continue
+		}
+		if i+1 < len(input) && input[i+1] == quote {
+			b.WriteByte(quote)
+			i++
+			continue
+		}
+		return b.String(), i + 1, true
+	}
+	return "", 0, false
+}
+
+// ---------- 语法分析 ----------
+
+type filterParser struct {
+	tokens        []filterToken
+	pos           int
+	columns       []ColumnSpec
+	defaultColumn int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword 判断当前词法单元是否为指定关键字（不区分大小写）
+func (tok filterToken) isKeyword(kw string) bool {
+	return tok.kind == tokWord && strings.EqualFold(tok.text, kw)
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	if p.peek().isKeyword("not") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (FilterExpr, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &FilterError{Pos: closing.pos, Msg: "缺少右括号"}
+		}
+		return expr, nil
+	case tokWord, tokString, tokColumn:
+		if tok.kind == tokWord && (tok.isKeyword("and") || tok.isKeyword("or")) {
+			return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("%s 前缺少条件", tok.text)}
+		}
+		p.next()
+		if op, ok := p.comparisonOp(); ok {
+			return p.parseComparison(tok, op)
+		}
+		if tok.kind == tokColumn {
+			return nil, &FilterError{Pos: p.peek().pos, Msg: "列名后缺少运算符"}
+		}
+		// 裸值：在默认列中匹配，非字符串列按值相等，否则做包含匹配
+		if p.defaultColumn < 0 || p.defaultColumn >= len(p.columns) {
+			return nil, &FilterError{Pos: tok.pos, Msg: "未指定列，请使用 列名 运算符 值 的形式"}
+		}
+		spec := p.columns[p.defaultColumn]
+		if value := spec.Parse(tok.text); spec.Type != ColumnString && value.Valid {
+			return compareExpr{col: p.defaultColumn, spec: spec, op: "=", value: value}, nil
+		}
+		return containsExpr{col: p.defaultColumn, name: spec.Name, text: tok.text}, nil
+	case tokEOF:
+		return nil, &FilterError{Pos: tok.pos, Msg: "表达式不完整"}
+	}
+	return nil, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("意外的 %q", tok.text)}
+}
+
+// comparisonOp 读取比较运算符，识别 contains、in、not in 等关键字形式
+func (p *filterParser) comparisonOp() (string, bool) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokOp:
+		p.next()
+		return tok.text, true
+	case tok.isKeyword("contains"), tok.isKeyword("in"):
+		p.next()
+		return strings.ToLower(tok.text), true
+	case tok.isKeyword("not") && p.tokens[p.pos+1].isKeyword("in"):
+		p.next()
+		p.next()
+		return "not in", true
+	}
+	return "", false
+}
+
+func (p *filterParser) parseComparison(lhs filterToken, op string) (FilterExpr, error) {
+	col := p.resolveColumn(lhs.text)
+	if col < 0 || lhs.kind == tokString {
+		return nil, &FilterError{Pos: lhs.pos, Msg: fmt.Sprintf("未知列 %q", lhs.text)}
+	}
+	spec := p.columns[col]
+
+	switch op {
+	case "in", "not in":
+		values, err := p.parseList(spec)
+		if err != nil {
+			return nil, err
+		}
+		return inExpr{col: col, spec: spec, values: values, negate: op == "not in"}, nil
+	}
+
+	lit, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "contains":
+		return containsExpr{col: col, name: spec.Name, text: lit.text}, nil
+	case "~", "!~":
+		re, err := regexp.Compile(lit.text)
+		if err != nil {
+			return nil, &FilterError{Pos: lit.pos, Msg: fmt.Sprintf("无效的正则表达式: %v", err)}
+		}
+		return regexExpr{col: col, name: spec.Name, re: re, negate: op == "!~"}, nil
+	}
+
+	value, err := parseLiteralValue(spec, lit)
+	if err != nil {
+		return nil, err
+	}
+	if op == "==" {
+		op = "="
+	} else if op == "<>" {
+		op = "!="
+	}
+	return compareExpr{col: col, spec: spec, op: op, value: value}, nil
+}
+
+// parseList 解析 in 运算符右侧的值列表
+func (p *filterParser) parseList(spec ColumnSpec) ([]Value, error) {
+	if open := p.next(); open.kind != tokLParen {
+		return nil, &FilterError{Pos: open.pos, Msg: "in 后应为 (值, ...)"}
+	}
+	var values []Value
+	for {
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseLiteralValue(spec, lit)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		sep := p.next()
+		if sep.kind == tokRParen {
+			return values, nil
+		}
+		if sep.kind != tokComma {
+			return nil, &FilterError{Pos: sep.pos, Msg: "值列表中应为 , 或 )"}
+		}
+	}
+}
+
+func (p *filterParser) parseLiteral() (filterToken, error) {
+	tok := p.next()
+	if tok.kind != tokWord && tok.kind != tokString {
+		if tok.kind == tokEOF {
+			return tok, &FilterError{Pos: tok.pos, Msg: "缺少比较值"}
+		}
+		return tok, &FilterError{Pos: tok.pos, Msg: fmt.Sprintf("应为值，实际为 %q", tok.text)}
+	}
+	return tok, nil
+}
+
+// resolveColumn 按列名查找列，不区分大小写
+func (p *filterParser) resolveColumn(name string) int {
+	for i, spec := range p.columns {
+		if spec.Name == name {
+			return i
+		}
+	}
+	for i, spec := range p.columns {
+		if strings.EqualFold(spec.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseLiteralValue 按列类型解析字面量，类型不符时返回带位置的错误
+func parseLiteralValue(spec ColumnSpec, lit filterToken) (Value, error) {
+	// 引号包裹的空字符串表示空值，任何类型的列都可以使用
+	if lit.kind == tokString && strings.TrimSpace(lit.text) == "" {
+		return Value{Str: lit.text}, nil
+	}
+	value := spec.Parse(lit.text)
+	if !value.Valid && spec.Type != ColumnString {
+		return value, &FilterError{Pos: lit.pos, Msg: fmt.Sprintf("%q 不是有效的 %s 值", lit.text, spec.Type)}
+	}
+	value.Valid = true
+	return value, nil
+}
+
+// ---------- 表达式节点 ----------
+
+type andExpr struct{ left, right FilterExpr }
+
+func (e andExpr) Match(row table.Row) bool { return e.left.Match(row) && e.right.Match(row) }
+func (e andExpr) String() string           { return e.left.String() + " and " + e.right.String() }
+
+type orExpr struct{ left, right FilterExpr }
+
+func (e orExpr) Match(row table.Row) bool { return e.left.Match(row) || e.right.Match(row) }
+func (e orExpr) String() string           { return "(" + e.left.String() + " or " + e.right.String() + ")" }
+
+type notExpr struct{ inner FilterExpr }
+
+func (e notExpr) Match(row table.Row) bool { return !e.inner.Match(row) }
+func (e notExpr) String() string           { return "not " + e.inner.String() }
+
+type compareExpr struct {
+	col   int
+	spec  ColumnSpec
+	op    string
+	value Value
+}
+
+func (e compareExpr) Match(row table.Row) bool {
+	cell := e.spec.Parse(cellAt(row, e.col))
+	if !cell.Valid {
+		// 空值或无法解析的值只满足 !=，与空字符串比较时按相等处理
+		if strings.TrimSpace(e.value.Str) == "" {
+			return e.op == "=" || e.op == ">=" || e.op == "<="
+		}
+		return e.op == "!="
+	}
+	c := e.spec.Compare(cell, e.value)
+	switch e.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+func (e compareExpr) String() string {
+	return fmt.Sprintf("%s %s %s", quoteColumn(e.spec.Name), e.op, quoteLiteral(e.value.Str))
+}
+
+type containsExpr struct {
+	col  int
+	name string
+	text string
+}
+
+func (e containsExpr) Match(row table.Row) bool {
+	return strings.Contains(strings.ToLower(cellAt(row, e.col)), strings.ToLower(e.text))
+}
+
+func (e containsExpr) String() string {
+	return fmt.Sprintf("%s contains %s", quoteColumn(e.name), quoteLiteral(e.text))
+}
+
+type regexExpr struct {
+	col    int
+	name   string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (e regexExpr) Match(row table.Row) bool {
+	return e.re.MatchString(cellAt(row, e.col)) != e.negate
+}
+
+func (e regexExpr) String() string {
+	op := "~"
+	if e.negate {
+		op = "!~"
+	}
+	return fmt.Sprintf("%s %s %s", quoteColumn(e.name), op, quoteText(e.re.String(), '"'))
+}
+
+type inExpr struct {
+	col    int
+	spec   ColumnSpec
+	values []Value
+	negate bool
+}
+
+func (e inExpr) Match(row table.Row) bool {
+	cell := e.spec.Parse(cellAt(row, e.col))
+	blank := strings.TrimSpace(cell.Str) == ""
+	found := false
+	for _, v := range e.values {
+		// 空值 "" 匹配空白的单元格
+		if (!v.Valid && blank) || (cell.Valid && v.Valid && e.spec.Compare(cell, v) == 0) {
+			found = true
+			break
+		}
+	}
+	return found != e.negate
+}
+
+func (e inExpr) String() string {
+	parts := make([]string, len(e.values))
+	for i, v := range e.values {
+		parts[i] = quoteLiteral(v.Str)
+	}
+	op := "in"
+	if e.negate {
+		op = "not in"
+	}
+	return fmt.Sprintf("%s %s (%s)", quoteColumn(e.spec.Name), op, strings.Join(parts, ", "))
+}
+
+// quoteLiteral 为含空格、特殊字符或与关键字同名的字面量加引号，结果可以由 ParseFilter 解析回原值
+func quoteLiteral(s string) string {
+	if needsQuote(s) {
+		return quoteText(s, '"')
+	}
+	return s
+}
+
+// quoteColumn 为含空格、特殊字符或与关键字同名的列名加反引号
+func quoteColumn(name string) string {
+	if needsQuote(name) {
+		return quoteText(name, '`')
+	}
+	return name
+}
+
+// needsQuote 判断文本能否作为裸词书写
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, kw := range []string{"and", "or", "not", "in", "contains"} {
+		if strings.EqualFold(s, kw) {
+			return true
+		}
+	}
+	for _, r := range s {
+		if !isWordRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteText 用指定的引号包裹文本，文本中的引号连写两次，与 scanQuoted 对应
+func quoteText(s string, quote rune) string {
+	q := string(quote)
+	return q + strings.ReplaceAll(s, q, q+q) + q
+}
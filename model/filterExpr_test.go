@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestFilterStringRoundTrip 表达式的文本形式重新解析后得到相同的文本和匹配结果
+func TestFilterStringRoundTrip(t *testing.T) {
+	columns := []ColumnSpec{
+		{Name: "备注", Type: ColumnString},
+		{Name: "年龄", Type: ColumnInt},
+		{Name: "下单 时间", Type: ColumnString},
+	}
+	rows := []table.Row{
+		{`说"明`, "30", "a"},
+		{`C:\temp`, "31", "b"},
+		{`it's`, "", "c"},
+		{"and", "30", "d"},
+		{`"`, "5", "e"},
+	}
+	inputs := []string{
+		`备注 = "说""明"`,
+		`备注 = 'C:\temp'`,
+		`备注 = "it's"`,
+		`备注 in ("and", """", 'it''s')`,
+		`备注 contains "\"`,
+		`备注 ~ "^C:\\t"`,
+		"`下单 时间` = a or 年龄 = \"\"",
+		`not 年龄 in (30, "")`,
+	}
+	for _, input := range inputs {
+		expr, err := ParseFilter(input, columns, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		again, err := ParseFilter(expr.String(), columns, 0)
+		if err != nil {
+			t.Fatalf("%s -> %s: %v", input, expr, err)
+		}
+		if again.String() != expr.String() {
+			t.Errorf("%s: %s -> %s", input, expr, again)
+		}
+		for _, row := range rows {
+			if expr.Match(row) != again.Match(row) {
+				t.Errorf("%s -> %s: %v 的匹配结果不同", input, expr, row)
+			}
+		}
+	}
+}
+
+// TestValuesFilterRoundTrip 值分布生成的筛选条件重新解析后仍按原文匹配，"001" 与 "1" 是不同的值
+func TestValuesFilterRoundTrip(t *testing.T) {
+	rows := []table.Row{{"001"}, {"1"}, {" "}, {""}, {"a.b"}, {"axb"}, {`说"明`}, {"and"}, {"10"}}
+	cases := []struct {
+		spec   ColumnSpec
+		values []string
+		want   int // 匹配的行数
+	}{
+		{ColumnSpec{Name: "编号", Type: ColumnInt}, []string{"001"}, 1},
+		{ColumnSpec{Name: "编号", Type: ColumnInt}, []string{"1", ""}, 3},
+		{ColumnSpec{Name: "下单 时间", Type: ColumnFloat}, []string{"1", "10"}, 2},
+		{ColumnSpec{Name: "编号", Type: ColumnInt}, []string{"a.b", `说"明`}, 2},
+		{ColumnSpec{Name: "备注", Type: ColumnString}, []string{"001"}, 1},
+		{ColumnSpec{Name: "备注", Type: ColumnString}, []string{"", "and", `说"明`}, 4},
+	}
+	for _, c := range cases {
+		expr := valuesFilter(0, c.spec, c.values)
+		again, err := ParseFilter(expr.String(), []ColumnSpec{c.spec}, 0)
+		if err != nil {
+			t.Fatalf("%v -> %s: %v", c.values, expr, err)
+		}
+		if again.String() != expr.String() {
+			t.Errorf("%v: %s -> %s", c.values, expr, again)
+		}
+		matched := 0
+		for _, row := range rows {
+			if expr.Match(row) {
+				matched++
+			}
+			if expr.Match(row) != again.Match(row) {
+				t.Errorf("%v -> %s: %v 的匹配结果不同", c.values, expr, row)
+			}
+		}
+		if matched != c.want {
+			t.Errorf("%v: 匹配 %d 行，期望 %d", c.values, matched, c.want)
+		}
+	}
+}
+
+// TestFilterQuotedLiteral 引号内连写的引号表示引号本身，反斜杠保持原样
+func TestFilterQuotedLiteral(t *testing.T) {
+	columns := []ColumnSpec{{Name: "备注", Type: ColumnString}}
+	cases := []struct {
+		input string
+		cell  string
+	}{
+		{`备注 = "说""明"`, `说"明`},
+		{`备注 = 'it''s'`, `it's`},
+		{`备注 = "C:\temp\"`, `C:\temp\`},
+	}
+	for _, c := range cases {
+		expr, err := ParseFilter(c.input, columns, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", c.input, err)
+		}
+		if !expr.Match(table.Row{c.cell}) {
+			t.Errorf("%s 应匹配 %q", c.input, c.cell)
+		}
+	}
+	if _, err := ParseFilter(`备注 = "说""`, columns, 0); err == nil {
+		t.Error("未闭合的引号应返回错误")
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 筛选栏和筛选条件标签样式
+var (
+	filterBarStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205")).
+			Bold(true).
+			MarginLeft(2)
+
+	filterInputStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("205")).
+				Background(lipgloss.Color("0")).
+				Bold(true)
+
+	filterChipStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("231")).
+			Background(lipgloss.Color("60")).
+			Padding(0, 1)
+
+	activeFilterChipStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("16")).
+				Background(lipgloss.Color("212")).
+				Bold(true).
+				Padding(0, 1)
+)
+
+// AddFilter 解析筛选表达式并追加到筛选条件列表，多个条件之间为“且”的关系
+func (m *TableModel) AddFilter(text string) error {
+	expr, err := ParseFilter(text, m.columnSpecs(), m.FilterColumn)
+	if err != nil {
+		return err
+	}
+	m.Filters = append(m.Filters, expr)
+	m.ApplyFilter()
+	return nil
+}
+
+// RemoveFilter 删除指定位置的筛选条件
+func (m *TableModel) RemoveFilter(i int) {
+	if i < 0 || i >= len(m.Filters) {
+		return
+	}
+	removed := m.Filters[i]
+	m.Filters = append(m.Filters[:i:i], m.Filters[i+1:]...)
+	m.ApplyFilter()
+	m.StatusMsg = fmt.Sprintf("已移除筛选条件 [%s]", removed)
+}
+
+// ClearFilters 清除全部筛选条件
+func (m *TableModel) ClearFilters() {
+	m.Filters = nil
+	m.ApplyFilter()
+}
+
+// ApplyFilter 从全部数据重新计算满足所有筛选条件的行，并保持当前排序
+// 数据量较大时在后台执行，完成前继续显示原来的结果
+func (m *TableModel) ApplyFilter() {
+	m.refreshRows(true)
+}
+
+// validateFilterInput 解析筛选栏中的输入，记录解析错误
+func (m *TableModel) validateFilterInput() {
+	m.FilterErr = nil
+	if value := m.TextInput.Value(); strings.TrimSpace(value) != "" {
+		_, m.FilterErr = ParseFilter(value, m.columnSpecs(), m.FilterColumn)
+	}
+}
+
+// renderFilterChips 将筛选条件渲染为可删除的标签，选择模式下高亮当前标签
+func (m TableModel) renderFilterChips() string {
+	chips := make([]string, 0, len(m.Filters))
+	for i, f := range m.Filters {
+		style := m.Theme.FilterChip
+		if m.PickingFilter && i == m.FilterChipCursor {
+			style = m.Theme.ActiveFilterChip
+		}
+		chips = append(chips, style.Render(fmt.Sprintf("%d. %s ×", i+1, f)))
+	}
+	return strings.Join(chips, " ")
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+// filterData 筛选测试使用的数据
+var filterData = TableData{
+	Headers: []string{"姓名", "城市", "年龄"},
+	Rows: [][]string{
+		{"张三", "北京", "30"}, {"李四", "上海", "25"},
+		{"王五", "北京", "41"}, {"赵六", "深圳", "35"},
+	},
+}
+
+// TestFilterStack 条件逐个叠加，可以移除最后一个、选择移除某一个或全部清除
+func TestFilterStack(t *testing.T) {
+	cases := []struct {
+		name    string
+		keys    []string
+		filters []string
+		rows    int
+	}{
+		{"叠加", []string{"f", "城市 = 北京", "enter", "f", "年龄 > 35", "enter"}, []string{"城市 = 北京", "年龄 > 35"}, 1},
+		{"裸值按当前列匹配", []string{"right", "f", "深圳", "enter"}, []string{"城市 contains 深圳"}, 1},
+		{"表达式有误时停留在筛选栏", []string{"f", "年龄 >", "enter"}, nil, 4},
+		{"取消输入", []string{"f", "城市 = 北京", "esc"}, nil, 4},
+		{"移除最后一个", []string{"f", "城市 = 北京", "enter", "f", "年龄 > 35", "enter", "x"}, []string{"城市 = 北京"}, 2},
+		{"选择移除", []string{"f", "城市 = 北京", "enter", "f", "年龄 > 35", "enter", "X", "left", "enter"}, []string{"年龄 > 35"}, 1},
+		{"全部清除", []string{"f", "城市 = 北京", "enter", "f", "年龄 > 35", "enter", "r"}, nil, 4},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, filterData), c.keys...)
+		var filters []string
+		for _, f := range m.Filters {
+			filters = append(filters, f.String())
+		}
+		if strings.Join(filters, "; ") != strings.Join(c.filters, "; ") {
+			t.Errorf("%s: 筛选条件 %q，期望 %q", c.name, filters, c.filters)
+		}
+		if len(m.OriginalRows) != c.rows {
+			t.Errorf("%s: 显示 %d 行，期望 %d", c.name, len(m.OriginalRows), c.rows)
+		}
+	}
+}
+
+// TestFilterBarError 表达式有误时在筛选栏下方提示错误位置
+func TestFilterBarError(t *testing.T) {
+	m := press(t, newTestModel(t, filterData), "f", "年龄 > abc")
+	if m.FilterErr == nil {
+		t.Fatal("没有记录解析错误")
+	}
+	if view := m.View(); !strings.Contains(view, "^ ") {
+		t.Errorf("没有标出错误位置\n%s", view)
+	}
+}
+
+// TestFilterBarTheme 筛选栏的标签和输入框使用 Theme 中的样式
+func TestFilterBarTheme(t *testing.T) {
+	theme := DefaultTheme()
+	theme.FilterBar = theme.FilterBar.Transform(func(s string) string { return "<" + s + ">" })
+	theme.FilterInput = theme.FilterInput.Transform(func(s string) string { return "[" + s + "]" })
+	m := press(t, newTestModel(t, filterData, WithTheme(theme)), "f")
+	view := m.View()
+	if !strings.Contains(view, "<筛选中 (列: 姓名): >") || !strings.Contains(view, "["+m.TextInput.View()+"]") {
+		t.Errorf("筛选栏没有使用 Theme.FilterBar 和 Theme.FilterInput\n%s", view)
+	}
+}
@@ -0,0 +1,480 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 分组标题行的样式
+var groupHeaderStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("87")).
+	Bold(true)
+
+// AggFunc 分组汇总的计算方式
+type AggFunc int
+
+const (
+	AggSum AggFunc = iota // 总和
+	AggAvg                // 平均值
+	AggMin                // 最小值
+	AggMax                // 最大值
+)
+
+// String 返回计算方式的名称
+func (f AggFunc) String() string {
+	switch f {
+	case AggAvg:
+		return "平均"
+	case AggMin:
+		return "最小"
+	case AggMax:
+		return "最大"
+	}
+	return "总和"
+}
+
+// Aggregate 分组标题行中显示的汇总项，总和与平均值只对数值列有效
+type Aggregate struct {
+	Column string  // 列名，不区分大小写
+	Func   AggFunc // 计算方式
+}
+
+// rowGroup 分组列的值相同的一组行
+type rowGroup struct {
+	key    string   // 分组列的值，以 \x1f 连接
+	values []string // 分组列的值
+	rows   []int    // 组内各行在 OriginalRows 中的位置，保持当前的排序
+	aggs   []string // 各汇总项的显示文本，与 aggregates 一一对应
+}
+
+// groupLine 分组视图中的一行
+type groupLine struct {
+	group int // 所属的分组
+	row   int // 在 OriginalRows 中的位置，-1 表示分组标题行
+}
+
+// groupView 分组模式的状态
+type groupView struct {
+	columns  []int           // 分组列
+	groups   []rowGroup      // 按组内第一行出现的顺序排列
+	expanded map[string]bool // 已展开的分组，分组默认折叠
+	lines    []groupLine     // 展开后的标题行和数据行
+	cursor   int             // 光标所在的行
+	offset   int             // 第一个显示的行
+}
+
+// GroupBy 按指定列分组，多列时按各列的值组合分组；不指定列时取消分组
+func (m *TableModel) GroupBy(cols ...int) {
+	if len(cols) == 0 {
+		m.grouping = nil
+		return
+	}
+	m.grouping = &groupView{columns: cols, expanded: make(map[string]bool)}
+	m.rebuildGroups()
+}
+
+// SetGroupBy 按列名分组，列不存在时返回错误
+func (m *TableModel) SetGroupBy(names ...string) error {
+	cols, err := columnIndexes(names, m.Columns)
+	if err != nil {
+		return err
+	}
+	m.GroupBy(cols...)
+	return nil
+}
+
+// SetAggregates 设置分组标题行中显示的汇总项，列不存在时返回错误
+func (m *TableModel) SetAggregates(aggs ...Aggregate) error {
+	for _, agg := range aggs {
+		if _, err := columnIndexes([]string{agg.Column}, m.Columns); err != nil {
+			return err
+		}
+	}
+	m.aggregates = aggs
+	m.rebuildGroups()
+	return nil
+}
+
+// Grouped 是否处于分组模式
+func (m TableModel) Grouped() bool {
+	return m.grouping != nil
+}
+
+// ToggleGroupColumn 将光标所在列加入或移出分组列，移出最后一列时取消分组
+func (m *TableModel) ToggleGroupColumn() {
+	var cols []int
+	found := false
+	if m.grouping != nil {
+		for _, col := range m.grouping.columns {
+			if col == m.ColCursor {
+				found = true
+				continue
+			}
+			cols = append(cols, col)
+		}
+	}
+	if !found {
+		cols = append(cols, m.ColCursor)
+	}
+	m.GroupBy(cols...)
+	if len(cols) == 0 {
+		m.StatusMsg = "已取消分组"
+	} else {
+		m.StatusMsg = fmt.Sprintf("按 %s 分组，共 %d 组", m.groupColumnNames(), len(m.grouping.groups))
+	}
+}
+
+// groupColumnNames 返回分组列的列名
+func (m TableModel) groupColumnNames() string {
+	names := make([]string, len(m.grouping.columns))
+	for i, col := range m.grouping.columns {
+		names[i] = m.columnName(col)
+	}
+	return strings.Join(names, ", ")
+}
+
+// rebuildGroups 按当前显示的行重新分组并计算汇总，显示行变化后调用
+func (m *TableModel) rebuildGroups() {
+	g := m.grouping
+	if g == nil {
+		return
+	}
+
+	index := make(map[string]int)
+	g.groups = nil
+	// 没有数据时显示行中只有“无数据”占位行，不参与分组
+	if m.RowCount == 0 {
+		m.layoutGroups()
+		return
+	}
+	for r, row := range m.OriginalRows {
+		values := make([]string, len(g.columns))
+		for i, col := range g.columns {
+			values[i] = cellAt(row, col)
+		}
+		k := strings.Join(values, "\x1f")
+		gi, ok := index[k]
+		if !ok {
+			gi = len(g.groups)
+			index[k] = gi
+			g.groups = append(g.groups, rowGroup{key: k, values: values})
+		}
+		g.groups[gi].rows = append(g.groups[gi].rows, r)
+	}
+	// 汇总列在每次分组时查找一次，列不存在的汇总项为 -1
+	aggCols := make([]int, len(m.aggregates))
+	for i, agg := range m.aggregates {
+		aggCols[i] = -1
+		if cols, err := columnIndexes([]string{agg.Column}, m.Columns); err == nil {
+			aggCols[i] = cols[0]
+		}
+	}
+	for i := range g.groups {
+		g.groups[i].aggs = m.aggregateGroup(g.groups[i].rows, aggCols)
+	}
+	m.layoutGroups()
+}
+
+// aggregateGroup 计算一组行的各汇总项，cols 为各汇总项所在的列，没有有效值时显示为 -
+func (m TableModel) aggregateGroup(rows, cols []int) []string {
+	results := make([]string, len(m.aggregates))
+	for i, agg := range m.aggregates {
+		results[i] = "-"
+		col := cols[i]
+		if col < 0 {
+			continue
+		}
+		spec := m.columnSpec(col)
+		if (agg.Func == AggSum || agg.Func == AggAvg) && !spec.Type.IsNumeric() {
+			continue
+		}
+
+		var sum float64
+		var best Value
+		n := 0
+		for _, r := range rows {
+			v := spec.Parse(cellAt(m.OriginalRows[r], col))
+			if !v.Valid {
+				continue
+			}
+			sum += spec.number(v)
+			if n == 0 || (agg.Func == AggMin && spec.Compare(v, best) < 0) || (agg.Func == AggMax && spec.Compare(v, best) > 0) {
+				best = v
+			}
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		switch agg.Func {
+		case AggSum:
+			results[i] = formatStat(spec, sum)
+		case AggAvg:
+			results[i] = formatStat(spec, sum/float64(n))
+		default:
+			results[i] = spec.FormatCell(best.Str)
+		}
+	}
+	return results
+}
+
+// layoutGroups 按展开状态生成分组视图的各行，光标尽量停留在原来的记录上
+func (m *TableModel) layoutGroups() {
+	g := m.grouping
+	g.lines = g.lines[:0]
+	for gi, group := range g.groups {
+		g.lines = append(g.lines, groupLine{group: gi, row: -1})
+		if g.expanded[group.key] {
+			for _, r := range group.rows {
+				g.lines = append(g.lines, groupLine{group: gi, row: r})
+			}
+		}
+	}
+	m.followGroupCursor()
+}
+
+// groupLineRow 返回分组视图中一行对应的 OriginalRows 位置，标题行对应组内第一行
+func (g *groupView) groupLineRow(i int) int {
+	line := g.lines[i]
+	if line.row >= 0 {
+		return line.row
+	}
+	return g.groups[line.group].rows[0]
+}
+
+// followGroupCursor 让分组视图的光标落在表格光标所在的记录上，记录所在的组折叠时停在标题行
+func (m *TableModel) followGroupCursor() {
+	g := m.grouping
+	if g == nil || len(g.lines) == 0 {
+		return
+	}
+	g.cursor = max(min(g.cursor, len(g.lines)-1), 0)
+	r := m.Cursor.Index()
+	if g.groupLineRow(g.cursor) != r {
+		for i := range g.lines {
+			line := g.lines[i]
+			if line.row == r || (line.row < 0 && !g.expanded[g.groups[line.group].key] && containsRow(g.groups[line.group].rows, r)) {
+				g.cursor = i
+				break
+			}
+		}
+	}
+	m.clampGroupView()
+}
+
+// containsRow 判断组内是否包含指定行
+func containsRow(rows []int, r int) bool {
+	for _, row := range rows {
+		if row == r {
+			return true
+		}
+	}
+	return false
+}
+
+// moveGroupCursor 移动分组视图的光标，表格光标随之移动到对应的记录
+func (m *TableModel) moveGroupCursor(delta int) {
+	g := m.grouping
+	if len(g.lines) == 0 {
+		return
+	}
+	g.cursor = max(min(g.cursor+delta, len(g.lines)-1), 0)
+	m.Cursor.Set(g.groupLineRow(g.cursor))
+	m.clampGroupView()
+}
+
+// clampGroupView 调整滚动位置，使光标行落在可见范围内
+func (m *TableModel) clampGroupView() {
+	g := m.grouping
+	height := m.bodyHeight()
+	if g.cursor < g.offset {
+		g.offset = g.cursor
+	} else if g.cursor >= g.offset+height {
+		g.offset = g.cursor - height + 1
+	}
+	g.offset = max(min(g.offset, len(g.lines)-height), 0)
+}
+
+// ToggleGroupFold 展开或折叠光标所在的分组，折叠后光标停在标题行
+func (m *TableModel) ToggleGroupFold() {
+	g := m.grouping
+	if g == nil || len(g.lines) == 0 {
+		return
+	}
+	group := g.groups[g.lines[g.cursor].group]
+	if g.expanded[group.key] {
+		delete(g.expanded, group.key)
+		m.Cursor.Set(group.rows[0])
+	} else {
+		g.expanded[group.key] = true
+	}
+	m.layoutGroups()
+}
+
+// ToggleAllGroups 有折叠的分组时全部展开，否则全部折叠
+func (m *TableModel) ToggleAllGroups() {
+	g := m.grouping
+	if g == nil {
+		return
+	}
+	collapsed := false
+	for _, group := range g.groups {
+		if !g.expanded[group.key] {
+			collapsed = true
+			break
+		}
+	}
+	g.expanded = make(map[string]bool)
+	if collapsed {
+		for _, group := range g.groups {
+			g.expanded[group.key] = true
+		}
+	} else if len(g.lines) > 0 {
+		m.Cursor.Set(g.groups[g.lines[g.cursor].group].rows[0])
+	}
+	m.layoutGroups()
+}
+
+// updateGrouping 处理分组模式下的移动和展开/折叠，返回按键是否已处理
+func (m *TableModel) updateGrouping(msg tea.KeyMsg) bool {
+	page := max(m.bodyHeight()-1, 1)
+	switch {
+	case key.Matches(msg, m.Keys.Up):
+		m.moveGroupCursor(-1)
+	case key.Matches(msg, m.Keys.Down):
+		m.moveGroupCursor(1)
+	case key.Matches(msg, m.Keys.PageUp), key.Matches(msg, rowCursorKeys.PageUp):
+		m.moveGroupCursor(-page)
+	case key.Matches(msg, m.Keys.PageDown), key.Matches(msg, rowCursorKeys.PageDown):
+		m.moveGroupCursor(page)
+	case key.Matches(msg, rowCursorKeys.GotoTop):
+		m.moveGroupCursor(-len(m.grouping.lines))
+	case key.Matches(msg, rowCursorKeys.GotoBottom):
+		m.moveGroupCursor(len(m.grouping.lines))
+	case key.Matches(msg, m.Keys.Fold):
+		m.ToggleGroupFold()
+	case key.Matches(msg, m.Keys.FoldAll):
+		m.ToggleAllGroups()
+	default:
+		return false
+	}
+	return true
+}
+
+// groupTitle 返回分组标题行的文本：分组列的值、行数和汇总项
+func (m TableModel) groupTitle(group rowGroup) string {
+	g := m.grouping
+	parts := make([]string, len(g.columns))
+	for i, col := range g.columns {
+		value := m.columnSpec(col).FormatCell(group.values[i])
+		if strings.TrimSpace(value) == "" {
+			value = blankValueText
+		}
+		parts[i] = fmt.Sprintf("%s = %s", m.columnName(col), value)
+	}
+	title := fmt.Sprintf("%s  (%d 行)", strings.Join(parts, ", "), len(group.rows))
+	for i, agg := range m.aggregates {
+		title += fmt.Sprintf("  %s %s: %s", agg.Column, agg.Func, group.aggs[i])
+	}
+	return title
+}
+
+// groupedView 渲染分组模式的表头、分组标题行和展开的数据行
+func (m TableModel) groupedView() string {
+	g := m.grouping
+	styles := m.Theme.Table
+	start, end := m.visibleColumnRange()
+	lines := []string{m.renderHeader(start, end)}
+
+	width := lipgloss.Width(lines[0])
+	height := m.bodyHeight()
+	for i := g.offset; i < len(g.lines) && i < g.offset+height; i++ {
+		line := g.lines[i]
+		if line.row >= 0 {
+			lines = append(lines, m.renderRow(line.row, start, end, i == g.cursor, styles))
+			continue
+		}
+		group := g.groups[line.group]
+		marker := "▸ "
+		if g.expanded[group.key] {
+			marker = "▾ "
+		}
+		text := fitCell(marker+m.groupTitle(group), width, lipgloss.Left)
+		if i == g.cursor {
+			lines = append(lines, styles.Selected.Render(text))
+		} else {
+			lines = append(lines, m.Theme.GroupHeader.Render(text))
+		}
+	}
+	if len(g.lines) == 0 {
+		lines = append(lines, m.Theme.Info.Render(m.emptyText))
+	}
+	for len(lines) < height+1 {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// groupStatus 返回信息行中的分组说明
+func (m TableModel) groupStatus() string {
+	if m.grouping == nil {
+		return ""
+	}
+	return fmt.Sprintf("分组: %s (%d 组)", m.groupColumnNames(), len(m.grouping.groups))
+}
+
+// groupedRows 按分组的顺序返回全部数据行，用于导出明细
+func (m TableModel) groupedRows() []SelectedRow {
+	var rows []SelectedRow
+	for _, group := range m.grouping.groups {
+		for _, r := range group.rows {
+			rows = append(rows, SelectedRow{Index: m.RowIndex[r], Values: m.OriginalRows[r]})
+		}
+	}
+	return rows
+}
+
+// GroupExportPath 返回分组汇总的导出路径
+func (m TableModel) GroupExportPath() string {
+	return filepath.Join(m.exportDir(), "分组汇总.csv")
+}
+
+// ExportGroups 将每个分组的分组列的值、行数和汇总项导出为 CSV 文件
+func (m *TableModel) ExportGroups() error {
+	if m.grouping == nil {
+		return fmt.Errorf("未分组")
+	}
+	if err := os.MkdirAll(m.exportDir(), 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %v", err)
+	}
+
+	var b strings.Builder
+	var header []string
+	for _, col := range m.grouping.columns {
+		header = append(header, m.columnName(col))
+	}
+	header = append(header, "行数")
+	for _, agg := range m.aggregates {
+		header = append(header, fmt.Sprintf("%s(%s)", agg.Column, agg.Func))
+	}
+	b.WriteString(csvLine(header))
+	for _, group := range m.grouping.groups {
+		var values []string
+		for i, col := range m.grouping.columns {
+			values = append(values, m.columnSpec(col).FormatCell(group.values[i]))
+		}
+		values = append(values, fmt.Sprint(len(group.rows)))
+		values = append(values, group.aggs...)
+		b.WriteString(csvLine(values))
+	}
+	if err := os.WriteFile(m.GroupExportPath(), []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("写入 CSV 失败: %v", err)
+	}
+	return nil
+}
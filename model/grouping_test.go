@@ -0,0 +1,96 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+// groupData 分组测试使用的数据
+var groupData = TableData{
+	Headers: []string{"城市", "类型", "数量", "耗时", "备注"},
+	Rows: [][]string{
+		{"北京", "A", "10", "1s", "x"},
+		{"上海", "A", "20", "3s", "y"},
+		{"北京", "B", "30", "", "z"},
+		{"北京", "A", "", "2s", "w"},
+		{"上海", "B", "5", "4s", ""},
+	},
+}
+
+// TestGroupAggregates 按组计算汇总项，非数值列的总和与平均值以及没有有效值的组显示为 -
+func TestGroupAggregates(t *testing.T) {
+	cases := []struct {
+		name   string
+		by     []string
+		agg    Aggregate
+		keys   []string
+		values []string
+	}{
+		{"总和", []string{"城市"}, Aggregate{"数量", AggSum}, []string{"北京", "上海"}, []string{"40", "25"}},
+		{"平均值", []string{"城市"}, Aggregate{"数量", AggAvg}, []string{"北京", "上海"}, []string{"20", "12.5"}},
+		{"最小值", []string{"城市"}, Aggregate{"数量", AggMin}, []string{"北京", "上海"}, []string{"10", "5"}},
+		{"时长总和", []string{"城市"}, Aggregate{"耗时", AggSum}, []string{"北京", "上海"}, []string{"3s", "7s"}},
+		{"文本最大值", []string{"城市"}, Aggregate{"备注", AggMax}, []string{"北京", "上海"}, []string{"z", "y"}},
+		{"文本总和", []string{"城市"}, Aggregate{"备注", AggSum}, []string{"北京", "上海"}, []string{"-", "-"}},
+		{"多列分组", []string{"城市", "类型"}, Aggregate{"数量", AggSum}, []string{"北京\x1fA", "上海\x1fA", "北京\x1fB", "上海\x1fB"}, []string{"10", "20", "30", "5"}},
+		{"列名不区分大小写", []string{"城市"}, Aggregate{"数量", AggMax}, []string{"北京", "上海"}, []string{"30", "20"}},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, groupData)
+		if err := m.SetGroupBy(c.by...); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.SetAggregates(c.agg); err != nil {
+			t.Fatal(err)
+		}
+		var keys, values []string
+		for _, g := range m.grouping.groups {
+			keys = append(keys, g.key)
+			values = append(values, g.aggs[0])
+		}
+		if !reflect.DeepEqual(keys, c.keys) || !reflect.DeepEqual(values, c.values) {
+			t.Errorf("%s: 分组 %q 汇总 %q，期望 %q %q", c.name, keys, values, c.keys, c.values)
+		}
+	}
+}
+
+// TestSetAggregatesUnknownColumn 汇总列不存在时返回错误并保留原来的汇总项
+func TestSetAggregatesUnknownColumn(t *testing.T) {
+	m := newTestModel(t, groupData)
+	if err := m.SetAggregates(Aggregate{Column: "数量"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetAggregates(Aggregate{Column: "不存在"}); err == nil {
+		t.Error("没有返回错误")
+	}
+	if len(m.aggregates) != 1 || m.aggregates[0].Column != "数量" {
+		t.Errorf("汇总项为 %v", m.aggregates)
+	}
+}
+
+// TestGroupFold 分组默认折叠，展开后显示组内的行，没有数据时没有分组
+func TestGroupFold(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  TableData
+		keys  []string
+		lines int
+	}{
+		{"默认折叠", groupData, nil, 2},
+		{"展开第一组", groupData, []string{"tab"}, 5},
+		{"展开后再折叠", groupData, []string{"tab", "tab"}, 2},
+		{"全部展开", groupData, []string{"z"}, 7},
+		{"全部展开后全部折叠", groupData, []string{"z", "z"}, 2},
+		{"没有数据", TableData{Headers: groupData.Headers}, []string{"tab"}, 0},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, c.data)
+		if err := m.SetGroupBy("城市"); err != nil {
+			t.Fatal(err)
+		}
+		m = press(t, m, c.keys...)
+		if len(m.grouping.lines) != c.lines {
+			t.Errorf("%s: 显示 %d 行，期望 %d", c.name, len(m.grouping.lines), c.lines)
+		}
+	}
+}
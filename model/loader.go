@@ -0,0 +1,205 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Loader 查询函数，ctx 在用户取消或程序退出时被取消
+type Loader func(ctx context.Context) (TableData, error)
+
+// queryLoader 查询函数及重新构建表格所需的配置
+type queryLoader struct {
+	load Loader
+	ctx  context.Context
+	cfg  config
+}
+
+// loadRequestMsg 请求执行查询
+type loadRequestMsg struct{}
+
+// dataLoadedMsg 查询结束
+type dataLoadedMsg struct {
+	gen     int
+	data    TableData
+	err     error
+	elapsed time.Duration
+}
+
+// ShowLoader 执行查询并显示结果，查询期间显示计时，可以取消，失败时可以重试
+func ShowLoader(ctx context.Context, load Loader, opts ...Option) error {
+	cfg := newConfig(opts)
+	m := newLoaderModel(ctx, load, cfg)
+	_, err := runProgram(m, cfg.programOptions()...)
+	return err
+}
+
+// NewTableModelFromLoader 构建执行查询的表格模型，查询在 Init 返回的命令中开始
+func NewTableModelFromLoader(ctx context.Context, load Loader, opts ...Option) TableModel {
+	return newLoaderModel(ctx, load, newConfig(opts))
+}
+
+// newLoaderModel 构建尚未执行查询的表格模型
+func newLoaderModel(ctx context.Context, load Loader, cfg config) TableModel {
+	m := NewTableModel()
+	m.Title = cfg.title
+	m.Keys = cfg.keys
+	m.Theme = cfg.theme
+	m.Cursor.Focus()
+	m.loader = &queryLoader{load: load, ctx: ctx, cfg: cfg}
+	m.Querying = true // 查询在 Init 之后开始
+	m.refreshInterval = cfg.interval
+	m.SetSize(80, 24)
+	return m
+}
+
+// awaitingData 是否还在等待查询结果（查询中、失败或已取消）
+func (m TableModel) awaitingData() bool {
+	return m.loader != nil && !m.dataLoaded
+}
+
+// startLoad 执行查询，取代仍在执行的查询
+func (m *TableModel) startLoad() tea.Cmd {
+	if m.loader == nil {
+		return nil
+	}
+	if m.loadCancel != nil {
+		m.loadCancel()
+	}
+	ctx, cancel := context.WithCancel(m.loader.ctx)
+	m.loadCancel = cancel
+	m.loadGen++
+	m.Querying = true
+	m.LoadErr = nil
+	m.QueryDuration = 0
+	m.loadStart = time.Now()
+
+	gen, load, start := m.loadGen, m.loader.load, m.loadStart
+	work := func() tea.Msg {
+		data, err := load(ctx)
+		return dataLoadedMsg{gen: gen, data: data, err: err, elapsed: time.Since(start)}
+	}
+	return tea.Batch(work, m.Spinner.Tick)
+}
+
+// CancelLoad 取消正在执行的查询
+func (m *TableModel) CancelLoad() {
+	if !m.Querying {
+		return
+	}
+	if m.loadCancel != nil {
+		m.loadCancel()
+	}
+	m.loadGen++ // 丢弃之后返回的结果
+	m.Querying = false
+	if m.dataLoaded {
+		// 取消刷新时保留现有数据
+		m.StatusMsg = "已取消刷新"
+		return
+	}
+	m.LoadErr = context.Canceled
+	m.QueryDuration = time.Since(m.loadStart)
+}
+
+// handleDataLoaded 处理查询结果，首次查询成功时用新数据构建表格，刷新时替换数据行
+func (m *TableModel) handleDataLoaded(msg dataLoadedMsg) tea.Cmd {
+	if msg.gen != m.loadGen {
+		return nil
+	}
+	m.loadCancel()
+	m.Querying = false
+
+	if m.dataLoaded {
+		if msg.err == nil {
+			msg.err = m.applyRefresh(msg.data, msg.elapsed)
+		}
+		if msg.err != nil {
+			m.StatusMsg = fmt.Sprintf("刷新失败: %v", msg.err)
+			return nil
+		}
+		return m.clearChanges()
+	}
+
+	m.QueryDuration = msg.elapsed
+	if msg.err != nil {
+		m.LoadErr = msg.err
+		return nil
+	}
+	if err := m.loadData(msg.data, msg.elapsed); err != nil {
+		m.LoadErr = err
+	}
+	return nil
+}
+
+// loadData 用查询结果替换表格内容，保留尺寸、焦点和调用方设置的模式
+func (m *TableModel) loadData(data TableData, elapsed time.Duration) error {
+	fresh, err := newTableModel(data, m.loader.cfg)
+	if err != nil {
+		return err
+	}
+	fresh.loader = m.loader
+	fresh.dataLoaded = true
+	fresh.QueryDuration = elapsed
+	fresh.PickMode = m.PickMode
+	fresh.MultiSelect = m.MultiSelect
+	fresh.BulkActions = m.BulkActions
+	fresh.ShowHelp = m.ShowHelp
+	fresh.RefreshedAt = time.Now()
+	if !m.Focused() {
+		fresh.Blur()
+	}
+	fresh.SetSize(m.viewWidth, m.viewHeight)
+	*m = fresh
+	return nil
+}
+
+// updateLoading 等待查询结果期间只响应取消、重试、帮助和退出
+func (m *TableModel) updateLoading(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.Querying && key.Matches(msg, m.Keys.Cancel):
+		m.CancelLoad()
+	case !m.Querying && key.Matches(msg, m.Keys.Retry):
+		return m.startLoad()
+	case key.Matches(msg, m.Keys.Help):
+		m.ShowHelp = !m.ShowHelp
+	case key.Matches(msg, m.Keys.Quit):
+		return msgCmd(QuitRequestedMsg{})
+	}
+	return nil
+}
+
+// loadingView 查询中或查询失败时的界面
+func (m TableModel) loadingView() string {
+	var b strings.Builder
+
+	elapsed := m.QueryDuration.Truncate(100 * time.Millisecond)
+	b.WriteString(m.Theme.Title.Render(fmt.Sprintf("%s | 查询耗时: %v", m.Title, elapsed)))
+	b.WriteString("\n\n")
+
+	var hint string
+	switch {
+	case m.Querying:
+		b.WriteString(m.Theme.Info.Render(fmt.Sprintf("%s 正在查询… 已用时 %v", m.Spinner.View(), elapsed)))
+		hint = fmt.Sprintf("%s 取消查询", m.Keys.Cancel.Help().Key)
+	case errors.Is(m.LoadErr, context.Canceled):
+		b.WriteString(m.Theme.Status.Render("查询已取消"))
+		hint = fmt.Sprintf("%s 重新查询 | %s 退出", m.Keys.Retry.Help().Key, m.Keys.Quit.Help().Key)
+	default:
+		b.WriteString(m.Theme.Error.Render(fmt.Sprintf("查询失败: %v", m.LoadErr)))
+		hint = fmt.Sprintf("%s 重试 | %s 退出", m.Keys.Retry.Help().Key, m.Keys.Quit.Help().Key)
+	}
+	b.WriteString("\n")
+
+	// 填充空白使提示贴底
+	if padding := m.viewHeight - strings.Count(b.String(), "\n") - 1; padding > 0 {
+		b.WriteString(strings.Repeat("\n", padding))
+	}
+	b.WriteString(m.Theme.Help.Render(hint))
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	keyR     = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}
+	keyCtrlR = tea.KeyMsg{Type: tea.KeyCtrlR}
+)
+
+// TestLoaderRetry 查询失败后按 Retry 重新查询，与清除筛选的 r 不冲突
+func TestLoaderRetry(t *testing.T) {
+	cases := []struct {
+		name  string
+		key   tea.KeyMsg
+		retry bool
+	}{
+		{"ctrl+r 重试", keyCtrlR, true},
+		{"r 不重试", keyR, false},
+	}
+	for _, c := range cases {
+		m := NewTableModelFromLoader(context.Background(), func(context.Context) (TableData, error) {
+			return TableData{}, nil
+		})
+		m.startLoad()
+		m.handleDataLoaded(dataLoadedMsg{gen: m.loadGen, err: errors.New("连接失败")})
+		if m.Querying || m.LoadErr == nil {
+			t.Fatalf("%s: 查询失败后 Querying=%v LoadErr=%v", c.name, m.Querying, m.LoadErr)
+		}
+		if view := m.View(); !strings.Contains(view, "ctrl+r") {
+			t.Errorf("%s: 失败界面没有提示重试键\n%s", c.name, view)
+		}
+
+		gen := m.loadGen
+		tm, _ := m.Update(c.key)
+		m = tm.(TableModel)
+		if retried := m.loadGen != gen && m.Querying && m.LoadErr == nil; retried != c.retry {
+			t.Errorf("%s: 重新查询为 %v，期望 %v", c.name, retried, c.retry)
+		}
+		if m.loadCancel != nil {
+			m.loadCancel()
+		}
+	}
+}
+
+// pagedSource 每页返回固定的行，不知道总行数
+type pagedSource struct{ TableData }
+
+func (pagedSource) Len() int { return -1 }
+
+// TestSourceRetry 数据源读取失败后 ctrl+r 重新读取，r 仍然清除筛选
+func TestSourceRetry(t *testing.T) {
+	cases := []struct {
+		name        string
+		key         tea.KeyMsg
+		retry       bool
+		keepFilters bool
+	}{
+		{"ctrl+r 重试", keyCtrlR, true, true},
+		{"r 清除筛选", keyR, false, false},
+	}
+	for _, c := range cases {
+		src := pagedSource{TableData{Headers: []string{"ID"}, Rows: [][]string{{"1"}, {"2"}}}}
+		m, err := NewTableModelFromSource(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.handleRowsLoaded(rowsLoadedMsg{gen: m.sourceGen, rows: src.Rows, limit: 2})
+		m.handleRowsLoaded(rowsLoadedMsg{gen: m.sourceGen, limit: 2, err: errors.New("连接断开")})
+		if !strings.Contains(m.StatusMsg, "ctrl+r") {
+			t.Errorf("%s: 状态消息没有提示重试键: %s", c.name, m.StatusMsg)
+		}
+		if err := m.AddFilter("ID = 1"); err != nil {
+			t.Fatal(err)
+		}
+
+		tm, _ := m.Update(c.key)
+		m = tm.(TableModel)
+		if retried := m.Loading && m.LoadErr == nil; retried != c.retry {
+			t.Errorf("%s: 重新读取为 %v，期望 %v", c.name, retried, c.retry)
+		}
+		if kept := len(m.Filters) == 1; kept != c.keepFilters {
+			t.Errorf("%s: 保留筛选为 %v，期望 %v", c.name, kept, c.keepFilters)
+		}
+		m.CancelFetch()
+	}
+}
@@ -0,0 +1,310 @@
+package model
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Feature 可单独开关的功能
+type Feature uint
+
+const (
+	FeatureSort      Feature = 1 << iota // 排序
+	FeatureFilter                        // 筛选
+	FeatureSearch                        // 全表搜索
+	FeatureExport                        // 导出 CSV
+	FeatureCopy                          // 复制到剪贴板
+	FeatureSelect                        // 多选
+	FeatureStats                         // 列统计
+	FeatureFrequency                     // 值分布
+	FeatureGroup                         // 分组
+	FeatureInspect                       // 单元格查看器
+	FeaturePane                          // 预览栏
+
+	AllFeatures = FeatureSort | FeatureFilter | FeatureSearch | FeatureExport | FeatureCopy | FeatureSelect |
+		FeatureStats | FeatureFrequency | FeatureGroup | FeatureInspect | FeaturePane
+)
+
+// 默认导出目录和空数据提示
+const (
+	DefaultExportDir = "output"
+	DefaultEmptyText = "无数据"
+)
+
+// Option 配置 ShowTable、PickRows 和 NewTableModelFromData 的选项
+type Option func(*config)
+
+// config 选项汇总后的配置
+type config struct {
+	keys      KeyMap
+	theme     Theme
+	minWidth  int
+	maxWidth  int
+	sortKeys  []SortKey
+	filters   []string
+	emptyText string
+	exportDir string
+	inline    bool
+	readOnly  bool
+	features  Feature
+	title     string
+	pageSize  int
+	reload    Loader
+	interval  time.Duration
+	pane      bool
+	paneWidth int
+	paneView  PaneRenderer
+	groupBy   []string
+	aggs      []Aggregate
+}
+
+// newConfig 以默认值为基础依次应用选项
+func newConfig(opts []Option) config {
+	cfg := config{
+		keys:      Keys,
+		theme:     DefaultTheme(),
+		minWidth:  MinColumnWidth,
+		maxWidth:  MaxColumnWidth,
+		emptyText: DefaultEmptyText,
+		exportDir: DefaultExportDir,
+		features:  AllFeatures,
+		pageSize:  DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// programOptions 返回运行程序所需的选项
+func (c config) programOptions() []tea.ProgramOption {
+	if c.inline {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithAltScreen()}
+}
+
+// WithTitle 设置表格标题，对 TableData 会覆盖其中的 Title
+func WithTitle(title string) Option {
+	return func(c *config) {
+		c.title = title
+	}
+}
+
+// WithPageSize 设置每次从数据源读取的行数
+func WithPageSize(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// WithReload 设置刷新数据用的查询函数，设置后可以按刷新键重新查询
+// ShowLoader 使用自身的查询函数刷新，不需要此选项
+func WithReload(load Loader) Option {
+	return func(c *config) {
+		c.reload = load
+	}
+}
+
+// WithAutoRefresh 按固定间隔自动刷新，需要配合 ShowLoader 或 WithReload 使用
+func WithAutoRefresh(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithDetailPane 在表格右侧显示预览栏，随光标移动显示当前行；width 为预览栏宽度，小于等于 0 时占总宽度的 40%
+func WithDetailPane(width int) Option {
+	return func(c *config) {
+		c.pane = true
+		c.paneWidth = width
+	}
+}
+
+// WithPaneRenderer 自定义预览栏的内容，默认显示当前行每一列的完整值
+func WithPaneRenderer(render PaneRenderer) Option {
+	return func(c *config) {
+		c.paneView = render
+	}
+}
+
+// WithGroupBy 按指定列分组显示，多列时按各列的值组合分组，列不存在时返回错误
+func WithGroupBy(columns ...string) Option {
+	return func(c *config) {
+		c.groupBy = columns
+	}
+}
+
+// WithAggregates 设置分组标题行中显示的汇总项，如 Aggregate{Column: "薪资", Func: AggSum}
+func WithAggregates(aggs ...Aggregate) Option {
+	return func(c *config) {
+		c.aggs = aggs
+	}
+}
+
+// WithKeyMap 使用自定义键盘映射
+func WithKeyMap(keys KeyMap) Option {
+	return func(c *config) {
+		c.keys = keys
+	}
+}
+
+// WithTheme 使用自定义配色
+func WithTheme(theme Theme) Option {
+	return func(c *config) {
+		c.theme = theme
+	}
+}
+
+// WithColumnWidth 设置列宽的全局上下限，小于等于 0 的值保留默认
+func WithColumnWidth(min, max int) Option {
+	return func(c *config) {
+		if min > 0 {
+			c.minWidth = min
+		}
+		if max > 0 {
+			c.maxWidth = max
+		}
+	}
+}
+
+// WithSort 设置初始排序，按优先级从高到低
+func WithSort(keys ...SortKey) Option {
+	return func(c *config) {
+		c.sortKeys = append(c.sortKeys, keys...)
+	}
+}
+
+// WithFilter 设置初始筛选表达式，语法与 f 键输入相同，不指定列名的值匹配第一列
+func WithFilter(exprs ...string) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, exprs...)
+	}
+}
+
+// WithEmptyText 设置没有数据时显示的文本
+func WithEmptyText(text string) Option {
+	return func(c *config) {
+		c.emptyText = text
+	}
+}
+
+// WithExportDir 设置 CSV 导出目录
+func WithExportDir(dir string) Option {
+	return func(c *config) {
+		c.exportDir = dir
+	}
+}
+
+// WithInline 在当前终端内联显示，不切换到备用屏幕
+func WithInline() Option {
+	return func(c *config) {
+		c.inline = true
+	}
+}
+
+// WithReadOnly 只读模式：禁用导出和批量操作，不产生任何副作用
+func WithReadOnly() Option {
+	return func(c *config) {
+		c.readOnly = true
+	}
+}
+
+// WithFeatures 只启用指定的功能，如 WithFeatures(FeatureSort|FeatureSearch)
+func WithFeatures(features Feature) Option {
+	return func(c *config) {
+		c.features = features
+	}
+}
+
+// applyConfig 将配置应用到模型
+func (m *TableModel) applyConfig(c config) error {
+	m.Keys = c.keys
+	m.Theme = c.theme
+	m.ExportDir = c.exportDir
+	m.ReadOnly = c.readOnly
+	m.Features = c.features
+	if m.ReadOnly {
+		m.Features &^= FeatureExport
+	}
+	m.refreshInterval = c.interval
+	m.ShowPane = c.pane
+	m.PaneWidth = c.paneWidth
+	m.PaneRenderer = c.paneView
+	if c.reload != nil {
+		m.loader = reloadLoader(c.reload, c)
+		m.dataLoaded = true
+		m.RefreshedAt = time.Now()
+	}
+	m.disableFeatureKeys()
+
+	m.GroupBy()
+	if err := m.SetAggregates(c.aggs...); err != nil {
+		return err
+	}
+	if len(c.groupBy) > 0 {
+		if err := m.SetGroupBy(c.groupBy...); err != nil {
+			return err
+		}
+	}
+
+	m.FilterColumn = m.ColCursor
+	for _, expr := range c.filters {
+		if err := m.AddFilter(expr); err != nil {
+			return err
+		}
+	}
+	if len(c.sortKeys) > 0 {
+		m.SetSort(c.sortKeys...)
+	}
+	return nil
+}
+
+// HasFeature 判断功能是否启用
+func (m TableModel) HasFeature(f Feature) bool {
+	return m.Features&f == f
+}
+
+// disableFeatureKeys 禁用未启用功能的按键，禁用后既不响应也不出现在帮助中
+func (m *TableModel) disableFeatureKeys() {
+	groups := []struct {
+		feature  Feature
+		bindings []*key.Binding
+	}{
+		{FeatureSort, []*key.Binding{&m.Keys.Sort, &m.Keys.SortAdd}},
+		{FeatureFilter, []*key.Binding{&m.Keys.Filter, &m.Keys.Reset, &m.Keys.Unfilter, &m.Keys.PickChip, &m.Keys.DiffOnly}},
+		{FeatureSearch, []*key.Binding{&m.Keys.Search, &m.Keys.NextHit, &m.Keys.PrevHit}},
+		{FeatureExport, []*key.Binding{&m.Keys.Export}},
+		{FeatureCopy, []*key.Binding{&m.Keys.Copy, &m.Keys.CopyRaw}},
+		{FeatureSelect, []*key.Binding{&m.Keys.Select, &m.Keys.SelectDown, &m.Keys.SelectUp,
+			&m.Keys.SelectAll, &m.Keys.Invert, &m.Keys.Deselect}},
+		{FeatureStats, []*key.Binding{&m.Keys.Stats}},
+		{FeatureFrequency, []*key.Binding{&m.Keys.Frequency}},
+		{FeatureGroup, []*key.Binding{&m.Keys.Group}},
+		{FeatureInspect, []*key.Binding{&m.Keys.Inspect}},
+		{FeaturePane, []*key.Binding{&m.Keys.Pane}},
+	}
+	for _, g := range groups {
+		if !m.HasFeature(g.feature) {
+			for _, b := range g.bindings {
+				b.SetEnabled(false)
+			}
+		}
+	}
+}
+
+// availableKeys 返回当前模式下的按键：没有查询函数时不能刷新，差异相关的按键只在差异模式下可用，
+// 展开/折叠只在分组时可用；只在副本上禁用，调用方的 KeyMap 保持不变
+func (m TableModel) availableKeys() KeyMap {
+	keys := m.Keys
+	keys.Refresh.SetEnabled(keys.Refresh.Enabled() && m.loader != nil)
+	keys.DiffOnly.SetEnabled(keys.DiffOnly.Enabled() && m.diff != nil)
+	keys.DiffDetail.SetEnabled(keys.DiffDetail.Enabled() && m.diff != nil)
+	keys.Fold.SetEnabled(keys.Fold.Enabled() && m.grouping != nil)
+	keys.FoldAll.SetEnabled(keys.FoldAll.Enabled() && m.grouping != nil)
+	return keys
+}
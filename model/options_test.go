@@ -0,0 +1,88 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestOptions 选项设置初始排序、筛选、分组和列宽，无效的选项返回错误
+func TestOptions(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    []Option
+		want    []string // 显示的 ID
+		wantErr string
+		check   func(m TableModel) bool
+	}{
+		{"默认", nil, []string{"1", "2", "3", "4"}, "", func(m TableModel) bool {
+			return m.minColWidth == MinColumnWidth && m.PageSize == DefaultPageSize && m.Features == AllFeatures
+		}},
+		{"排序", []Option{WithSort(SortKey{Column: 2, Desc: true}, SortKey{Column: 0})}, []string{"1", "4", "3", "2"}, "", nil},
+		{"筛选", []Option{WithFilter("城市 = 北京", "数量 > 20")}, []string{"1"}, "", nil},
+		{"不指定列名时匹配第一列", []Option{WithFilter("2")}, []string{"2"}, "", nil},
+		{"无效的筛选", []Option{WithFilter("数量 >")}, nil, "缺少比较值", nil},
+		{"分组列不存在", []Option{WithGroupBy("地区")}, nil, `"地区"`, nil},
+		{"列宽", []Option{WithColumnWidth(12, 0)}, []string{"1", "2", "3", "4"}, "", func(m TableModel) bool {
+			return m.minColWidth == 12 && m.maxColWidth == MaxColumnWidth
+		}},
+		{"无效的页大小", []Option{WithPageSize(0)}, []string{"1", "2", "3", "4"}, "", func(m TableModel) bool {
+			return m.PageSize == DefaultPageSize
+		}},
+		{"只读时禁用导出", []Option{WithReadOnly()}, []string{"1", "2", "3", "4"}, "", func(m TableModel) bool {
+			return m.ReadOnly && !m.HasFeature(FeatureExport) && m.HasFeature(FeatureSort)
+		}},
+	}
+	data := TableData{
+		Headers: []string{"ID", "城市", "数量"},
+		Rows:    [][]string{{"1", "北京", "30"}, {"2", "上海", "10"}, {"3", "北京", "20"}, {"4", "深圳", "30"}},
+	}
+	for _, c := range cases {
+		m, err := NewTableModelFromData(data, c.opts...)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("%s: 错误 %v，期望包含 %q", c.name, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		var got []string
+		for _, row := range m.OriginalRows {
+			got = append(got, row[0])
+		}
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: 显示 %v，期望 %v", c.name, got, c.want)
+		}
+		if c.check != nil && !c.check(m) {
+			t.Errorf("%s: 配置没有生效", c.name)
+		}
+	}
+}
+
+// TestWithFeatures 未启用功能的按键不响应，调用方的 KeyMap 不受影响
+func TestWithFeatures(t *testing.T) {
+	cases := []struct {
+		name string
+		keys []string
+		ok   func(m TableModel) bool
+	}{
+		{"排序已禁用", []string{"s"}, func(m TableModel) bool { return len(m.SortKeys) == 0 }},
+		{"筛选已禁用", []string{"f"}, func(m TableModel) bool { return !m.Filtering }},
+		{"多选已禁用", []string{"space"}, func(m TableModel) bool { return len(m.Selected) == 0 }},
+		{"搜索可用", []string{"/"}, func(m TableModel) bool { return m.Searching }},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, filterData, WithFeatures(FeatureSearch)), c.keys...)
+		if !c.ok(m) {
+			t.Errorf("%s: 按键处理不符合预期", c.name)
+		}
+		if m.Keys.Sort.Enabled() || !m.Keys.Search.Enabled() {
+			t.Errorf("%s: 模型的按键没有按功能禁用", c.name)
+		}
+	}
+	if !Keys.Sort.Enabled() || !Keys.Filter.Enabled() {
+		t.Error("默认 KeyMap 被修改")
+	}
+}
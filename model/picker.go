@@ -0,0 +1,92 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SelectedRow 选中的数据行
+type SelectedRow struct {
+	Index  int      `json:"index"`  // 在 TableData.Rows 中的原始索引
+	Values []string `json:"values"` // 行数据
+}
+
+// PrintFormat 选择结果的输出格式
+type PrintFormat string
+
+const (
+	PrintNone PrintFormat = ""     // 不输出
+	PrintTSV  PrintFormat = "tsv"  // 每行一条记录，字段以制表符分隔
+	PrintJSON PrintFormat = "json" // JSON 数组
+)
+
+// PickOptions 选择器选项
+type PickOptions struct {
+	Multi  bool        // 是否允许用空格多选
+	Print  PrintFormat // 确认后将选择结果写到 Output，便于在 shell 脚本中使用
+	Output io.Writer   // 选择结果的输出目标，默认为标准输出
+}
+
+// PickRows 以交互选择器的方式显示表格，返回用户选中的行
+// 回车确认：有多选时返回全部选中行，否则返回光标所在行；按 esc 取消时返回空结果。
+// 设置 Print 时界面绘制到标准错误，标准输出只包含选择结果，可以像 fzf 一样用于管道。
+// 其余显示相关的配置与 ShowTable 相同，通过 Option 传入。
+func PickRows(data TableData, opts PickOptions, options ...Option) ([]SelectedRow, error) {
+	cfg := newConfig(options)
+	m, err := newTableModel(data, cfg)
+	if err != nil {
+		return nil, err
+	}
+	m.PickMode = true
+	m.MultiSelect = opts.Multi
+
+	programOpts := cfg.programOptions()
+	if opts.Print != PrintNone {
+		programOpts = append(programOpts, tea.WithOutput(os.Stderr))
+	}
+
+	final, err := runProgram(m, programOpts...)
+	if err != nil {
+		return nil, err
+	}
+	picked := final.Picked
+
+	if opts.Print != PrintNone && len(picked) > 0 {
+		out := opts.Output
+		if out == nil {
+			out = os.Stdout
+		}
+		if err := WriteSelection(out, picked, opts.Print); err != nil {
+			return picked, err
+		}
+	}
+	return picked, nil
+}
+
+// WriteSelection 按指定格式写出选择结果
+func WriteSelection(w io.Writer, rows []SelectedRow, format PrintFormat) error {
+	switch format {
+	case PrintTSV:
+		for _, row := range rows {
+			values := make([]string, len(row.Values))
+			for i, v := range row.Values {
+				// 制表符和换行会破坏 TSV 结构，替换为空格
+				values[i] = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(v)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(values, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case PrintJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	return fmt.Errorf("不支持的输出格式: %q", format)
+}
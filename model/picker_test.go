@@ -0,0 +1,104 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+)
+
+// pick 在选择器中依次按键，回车确认时返回选择结果
+func pick(t *testing.T, m TableModel, keys ...string) []SelectedRow {
+	t.Helper()
+	p := programModel{table: m}
+	for _, k := range keys {
+		p.table = press(t, p.table, k)
+		if k != "enter" {
+			continue
+		}
+		// press 丢弃了命令，确认时重新按一次以取得 RowSelectedMsg
+		_, cmd := p.table.Update(keyMsg(k))
+		if cmd == nil {
+			continue
+		}
+		if msg, ok := cmd().(RowSelectedMsg); ok {
+			tm, _ := p.Update(msg)
+			p = tm.(programModel)
+		}
+	}
+	return p.table.Picked
+}
+
+// TestPicker 回车确认：有多选时返回全部选中行，否则返回光标所在行；索引为数据的原始位置
+func TestPicker(t *testing.T) {
+	data := TableData{
+		Headers: []string{"ID", "名称"},
+		Rows:    [][]string{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}},
+	}
+	cases := []struct {
+		name  string
+		multi bool
+		keys  []string
+		want  []int
+	}{
+		{"光标所在行", false, []string{"down", "enter"}, []int{1}},
+		{"单选时空格不选择", false, []string{"space", "space", "enter"}, []int{0}},
+		{"多选", true, []string{"space", "down", "space", "enter"}, []int{0, 2}},
+		{"多选按原始顺序返回", true, []string{"s", "s", "up", "space", "space", "enter"}, []int{0, 1}},
+		{"排序后按原始索引", false, []string{"s", "s", "up", "enter"}, []int{1}},
+		{"筛选后按原始索引", false, []string{"right", "f", "c", "enter", "enter"}, []int{2}},
+		{"没有确认", true, []string{"space"}, nil},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, data)
+		m.PickMode = true
+		m.MultiSelect = c.multi
+		picked := pick(t, m, c.keys...)
+		var got []int
+		for _, row := range picked {
+			got = append(got, row.Index)
+			if row.Values[0] != data.Rows[row.Index][0] {
+				t.Errorf("%s: 第 %d 行的值为 %v", c.name, row.Index, row.Values)
+			}
+		}
+		if len(got) != len(c.want) || (len(got) > 0 && !equalInts(got, c.want)) {
+			t.Errorf("%s: 选中 %v，期望 %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestWriteSelection 按格式写出选择结果，TSV 中的制表符和换行替换为空格
+func TestWriteSelection(t *testing.T) {
+	rows := []SelectedRow{{Index: 0, Values: []string{"1", "a\tb"}}, {Index: 2, Values: []string{"3", "多\n行"}}}
+	cases := []struct {
+		format  PrintFormat
+		want    string
+		wantErr bool
+	}{
+		{PrintTSV, "1\ta b\n3\t多 行\n", false},
+		{PrintJSON, "[\n  {\n    \"index\": 0,\n    \"values\": [\n      \"1\",\n      \"a\\tb\"\n    ]\n  },\n" +
+			"  {\n    \"index\": 2,\n    \"values\": [\n      \"3\",\n      \"多\\n行\"\n    ]\n  }\n]\n", false},
+		{"xml", "", true},
+	}
+	for _, c := range cases {
+		var b bytes.Buffer
+		err := WriteSelection(&b, rows, c.format)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: 错误 %v", c.format, err)
+		}
+		if b.String() != c.want {
+			t.Errorf("%s: 输出\n%s\n期望\n%s", c.format, b.String(), c.want)
+		}
+	}
+}
+
+// equalInts 判断两个整数切片是否相同
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowSelectedMsg 按回车确认选择时发出，包含选中的行（无多选时为光标所在行）
+type RowSelectedMsg struct {
+	Rows []SelectedRow
+}
+
+// FilterChangedMsg 筛选条件变化时发出
+type FilterChangedMsg struct {
+	Filters []FilterExpr // 当前生效的筛选条件
+	Rows    int          // 筛选后的行数
+}
+
+// SortChangedMsg 排序栈变化时发出
+type SortChangedMsg struct {
+	Keys []SortKey
+}
+
+// QuitRequestedMsg 用户按下退出键时发出，是否真正退出由外层程序决定
+type QuitRequestedMsg struct{}
+
+// msgCmd 将消息包装为命令
+func msgCmd(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
+
+// changeEvents 比较处理消息前后的状态，为发生变化的筛选和排序生成通知消息
+func (m TableModel) changeEvents(before TableModel) []tea.Cmd {
+	var cmds []tea.Cmd
+	if filtersSignature(before.Filters) != filtersSignature(m.Filters) {
+		filters := append([]FilterExpr(nil), m.Filters...)
+		cmds = append(cmds, msgCmd(FilterChangedMsg{Filters: filters, Rows: len(m.OriginalRows)}))
+	}
+	if !sortKeysEqual(before.SortKeys, m.SortKeys) {
+		keys := append([]SortKey(nil), m.SortKeys...)
+		cmds = append(cmds, msgCmd(SortChangedMsg{Keys: keys}))
+	}
+	return cmds
+}
+
+// filtersSignature 返回筛选条件列表的文本表示，用于判断是否变化
+func filtersSignature(filters []FilterExpr) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = f.String()
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// sortKeysEqual 判断两个排序栈是否相同
+func sortKeysEqual(a, b []SortKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// programModel 独立运行时的顶层模型，把表格组件发出的消息转换为程序行为
+type programModel struct {
+	table TableModel
+}
+
+func (p programModel) Init() tea.Cmd {
+	return p.table.Init()
+}
+
+func (p programModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case QuitRequestedMsg:
+		p.table.CancelLoad()
+		p.table.CancelFetch()
+		return p, tea.Quit
+	case RowSelectedMsg:
+		// 选择器模式下确认即退出
+		if p.table.PickMode {
+			p.table.Picked = msg.Rows
+			return p, tea.Quit
+		}
+	}
+
+	tm, cmd := p.table.Update(msg)
+	p.table = tm.(TableModel)
+	return p, cmd
+}
+
+func (p programModel) View() string {
+	return p.table.View()
+}
+
+// runProgram 以独立程序运行表格组件，返回退出时的模型状态
+func runProgram(m TableModel, opts ...tea.ProgramOption) (TableModel, error) {
+	final, err := tea.NewProgram(programModel{table: m}, opts...).Run()
+	if err != nil {
+		return m, err
+	}
+	return final.(programModel).table, nil
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// collectMsgs 执行命令并展开批量命令，返回产生的消息；等待计时器的命令被忽略
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(20 * time.Millisecond):
+		return nil
+	}
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collectMsgs(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+// TestChangeEvents 筛选、排序变化和退出时向外层程序发出通知消息
+func TestChangeEvents(t *testing.T) {
+	cases := []struct {
+		name string
+		pick bool
+		keys []string // 先按下的键
+		last string   // 检查这个键产生的消息
+		want []tea.Msg
+	}{
+		{"排序", false, nil, "s", []tea.Msg{SortChangedMsg{Keys: []SortKey{{Column: 0}}}}},
+		{"倒序", false, []string{"s"}, "s", []tea.Msg{SortChangedMsg{Keys: []SortKey{{Column: 0, Desc: true}}}}},
+		{"取消排序", false, []string{"s", "s"}, "s", []tea.Msg{SortChangedMsg{Keys: []SortKey{}}}},
+		{"筛选", false, []string{"right", "f", "x"}, "enter", []tea.Msg{FilterChangedMsg{
+			Filters: []FilterExpr{mustParseFilter(t, "x", selectionData, 1)}, Rows: 3}}},
+		{"输入筛选条件时不通知", false, []string{"right", "f"}, "x", nil},
+		{"移动光标不通知", false, nil, "down", nil},
+		{"退出", false, nil, "esc", []tea.Msg{QuitRequestedMsg{}}},
+		{"选择器确认", true, []string{"down"}, "enter", []tea.Msg{RowSelectedMsg{
+			Rows: []SelectedRow{{Index: 1, Values: []string{"2", "y"}}}}}},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, selectionData)
+		m.PickMode = c.pick
+		m = press(t, m, c.keys...)
+		_, cmd := m.Update(keyMsg(c.last))
+
+		var got []tea.Msg
+		for _, msg := range collectMsgs(cmd) {
+			switch msg.(type) {
+			case FilterChangedMsg, SortChangedMsg, QuitRequestedMsg, RowSelectedMsg:
+				got = append(got, msg)
+			}
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: 消息 %#v，期望 %#v", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if !eventEqual(got[i], c.want[i]) {
+				t.Errorf("%s: 消息 %#v，期望 %#v", c.name, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+// eventEqual 比较通知消息，筛选条件按文本比较
+func eventEqual(a, b tea.Msg) bool {
+	fa, ok1 := a.(FilterChangedMsg)
+	fb, ok2 := b.(FilterChangedMsg)
+	if ok1 && ok2 {
+		return fa.Rows == fb.Rows && filtersSignature(fa.Filters) == filtersSignature(fb.Filters)
+	}
+	if sa, ok := a.(SortChangedMsg); ok && len(sa.Keys) == 0 {
+		sb, ok := b.(SortChangedMsg)
+		return ok && len(sb.Keys) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// mustParseFilter 按数据的列定义解析筛选表达式，未指定列时作用于第 col 列
+func mustParseFilter(t *testing.T, text string, data TableData, col int) FilterExpr {
+	t.Helper()
+	expr, err := ParseFilter(text, resolveColumnSpecs(data), col)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return expr
+}
+
+// TestProgramModel 顶层模型处理退出请求和选择器的确认
+func TestProgramModel(t *testing.T) {
+	selected := RowSelectedMsg{Rows: []SelectedRow{{Index: 2, Values: []string{"3", "x"}}}}
+	cases := []struct {
+		name       string
+		pick       bool
+		msg        tea.Msg
+		wantQuit   bool
+		wantPicked []SelectedRow
+	}{
+		{"退出请求", false, QuitRequestedMsg{}, true, nil},
+		{"选择器确认后退出", true, selected, true, selected.Rows},
+		{"非选择器模式不退出", false, selected, false, nil},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, selectionData)
+		m.PickMode = c.pick
+		tm, cmd := programModel{table: m}.Update(c.msg)
+		quit := false
+		for _, msg := range collectMsgs(cmd) {
+			if _, ok := msg.(tea.QuitMsg); ok {
+				quit = true
+			}
+		}
+		if quit != c.wantQuit {
+			t.Errorf("%s: 退出 %v，期望 %v", c.name, quit, c.wantQuit)
+		}
+		if picked := tm.(programModel).table.Picked; !reflect.DeepEqual(picked, c.wantPicked) {
+			t.Errorf("%s: 选择结果 %v，期望 %v", c.name, picked, c.wantPicked)
+		}
+	}
+}
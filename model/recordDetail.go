@@ -0,0 +1,301 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 记录详情中列名的样式
+var detailNameStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("87")).
+	Bold(true)
+
+// recordDetail 记录详情视图的状态，逐行显示光标所在记录每一列的完整值
+type recordDetail struct {
+	offset    int             // 第一条显示的内容行
+	searching bool            // 是否正在输入搜索文本
+	input     textinput.Model // 搜索输入框
+	query     string          // 搜索文本
+	hit       int             // 当前匹配在全部匹配中的序号
+}
+
+// detailLine 记录详情中的一行，长的值按宽度折成多行
+type detailLine struct {
+	col   int    // 所属的列
+	name  string // 列名，只在该列的第一行显示
+	value string // 值的一部分
+}
+
+// OpenDetail 打开光标所在记录的详情
+func (m *TableModel) OpenDetail() {
+	if m.RowCount == 0 {
+		return
+	}
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "搜索列名或值..."
+	input.CharLimit = 100
+	m.detail = &recordDetail{input: input}
+}
+
+// CloseDetail 关闭记录详情
+func (m *TableModel) CloseDetail() {
+	m.detail = nil
+}
+
+// DetailOpen 是否正在显示记录详情
+func (m TableModel) DetailOpen() bool {
+	return m.detail != nil
+}
+
+// updateDetail 处理记录详情中的按键：滚动、切换记录和搜索
+func (m *TableModel) updateDetail(msg tea.KeyMsg) tea.Cmd {
+	d := m.detail
+	if d.searching {
+		switch msg.String() {
+		case "enter":
+			d.searching = false
+			d.input.Blur()
+		case "esc":
+			d.searching = false
+			d.input.Blur()
+			d.query = ""
+		default:
+			var cmd tea.Cmd
+			d.input, cmd = d.input.Update(msg)
+			d.query = d.input.Value()
+			d.hit = 0
+			m.scrollToDetailHit()
+			return cmd
+		}
+		return nil
+	}
+
+	page := max(m.detailHeight()-1, 1)
+	switch {
+	case key.Matches(msg, m.Keys.Detail), key.Matches(msg, m.Keys.Cancel):
+		m.CloseDetail()
+	case key.Matches(msg, m.Keys.Up):
+		d.offset--
+	case key.Matches(msg, m.Keys.Down):
+		d.offset++
+	case key.Matches(msg, m.Keys.PageUp):
+		d.offset -= page
+	case key.Matches(msg, m.Keys.PageDown):
+		d.offset += page
+	case key.Matches(msg, m.Keys.Left):
+		m.moveDetail(-1)
+	case key.Matches(msg, m.Keys.Right):
+		m.moveDetail(1)
+	case key.Matches(msg, m.Keys.Search):
+		d.searching = true
+		d.input.SetValue(d.query)
+		d.input.CursorEnd()
+		d.input.Focus()
+		return textinput.Blink
+	case key.Matches(msg, m.Keys.NextHit):
+		d.hit++
+		m.scrollToDetailHit()
+	case key.Matches(msg, m.Keys.PrevHit):
+		d.hit--
+		m.scrollToDetailHit()
+	}
+	m.clampDetail()
+	return nil
+}
+
+// moveDetail 切换到上一条或下一条记录，保留搜索文本
+func (m *TableModel) moveDetail(delta int) {
+	m.Cursor.Move(delta)
+	m.EnsureCursorVisible()
+	m.detail.offset = 0
+	m.detail.hit = 0
+	if m.detail.query != "" {
+		m.scrollToDetailHit()
+	}
+}
+
+// detailWidth 返回记录详情可用的宽度
+func (m TableModel) detailWidth() int {
+	if m.detailInPane() {
+		return m.paneContentWidth()
+	}
+	return max(m.viewWidth-4, 20)
+}
+
+// detailHeight 返回记录详情中可显示的内容行数
+func (m TableModel) detailHeight() int {
+	// 预览栏中记录信息占一行
+	if m.detailInPane() {
+		return max(m.paneContentHeight()-1, 1)
+	}
+	// 标题、记录信息（搜索时为搜索栏）和帮助各占一行
+	return max(m.viewHeight-3, 1)
+}
+
+// detailState 返回记录详情的状态，在预览栏中显示而未打开时使用初始状态
+func (m TableModel) detailState() *recordDetail {
+	if m.detail == nil {
+		return &recordDetail{}
+	}
+	return m.detail
+}
+
+// detailLines 将光标所在记录按列展开，值按宽度折行，不截断
+func (m TableModel) detailLines(width int) ([]detailLine, int) {
+	r := m.Cursor.Index()
+	if m.RowCount == 0 || r >= len(m.OriginalRows) {
+		return nil, 0
+	}
+	row := m.OriginalRows[r]
+
+	nameWidth := 0
+	for _, col := range m.TableColumns {
+		nameWidth = max(nameWidth, DisplayWidth(col.Title))
+	}
+	nameWidth = min(nameWidth, width/3)
+	valueWidth := max(width-nameWidth-2, 8)
+	wrap := lipgloss.NewStyle().Width(valueWidth)
+
+	var lines []detailLine
+	for i, col := range m.TableColumns {
+		value := m.columnSpec(i).FormatCell(cellAt(row, i))
+		for j, part := range strings.Split(wrap.Render(value), "\n") {
+			line := detailLine{col: i, value: strings.TrimRight(part, " ")}
+			if j == 0 {
+				line.name = col.Title
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, nameWidth
+}
+
+// detailHits 返回列名或值中包含搜索文本的内容行
+func (m TableModel) detailHits(lines []detailLine) []int {
+	needle := []rune(strings.ToLower(m.detailState().query))
+	if len(needle) == 0 {
+		return nil
+	}
+	var hits []int
+	for i, line := range lines {
+		if len(matchSpans(line.name, needle)) > 0 || len(matchSpans(line.value, needle)) > 0 {
+			hits = append(hits, i)
+		}
+	}
+	return hits
+}
+
+// scrollToDetailHit 滚动到当前匹配，序号超出范围时循环
+func (m *TableModel) scrollToDetailHit() {
+	lines, _ := m.detailLines(m.detailWidth())
+	hits := m.detailHits(lines)
+	if len(hits) == 0 {
+		return
+	}
+	d := m.detail
+	d.hit = (d.hit%len(hits) + len(hits)) % len(hits)
+	line := hits[d.hit]
+	if height := m.detailHeight(); line < d.offset || line >= d.offset+height {
+		d.offset = line - height/2
+	}
+	m.clampDetail()
+}
+
+// clampDetail 将滚动位置限制在内容范围内
+func (m *TableModel) clampDetail() {
+	if m.detail == nil {
+		return
+	}
+	lines, _ := m.detailLines(m.detailWidth())
+	m.detail.offset = min(m.detail.offset, len(lines)-m.detailHeight())
+	m.detail.offset = max(m.detail.offset, 0)
+}
+
+// renderDetail 按指定宽度和高度渲染记录详情的内容，全屏和分栏布局共用
+func (m TableModel) renderDetail(width, height int) string {
+	d := m.detailState()
+	lines, nameWidth := m.detailLines(width)
+	hits := m.detailHits(lines)
+	needle := []rune(strings.ToLower(d.query))
+	current := -1
+	if len(hits) > 0 {
+		current = hits[(d.hit%len(hits)+len(hits))%len(hits)]
+	}
+
+	base := lipgloss.NewStyle()
+	out := make([]string, 0, height)
+	for i := d.offset; i < len(lines) && len(out) < height; i++ {
+		line := lines[i]
+		highlight := m.Theme.SearchMatch
+		if i == current {
+			highlight = m.Theme.CurrentMatch
+		}
+		nameStyle := m.Theme.FieldName
+		if line.col == m.ColCursor {
+			nameStyle = nameStyle.Inherit(m.Theme.ActiveHeader)
+		}
+		name := renderHighlighted(line.name, nameWidth, lipgloss.Left, nameStyle, highlight, matchSpans(TruncateWidth(line.name, nameWidth), needle))
+		value := renderHighlighted(line.value, width-nameWidth-2, lipgloss.Left, base, highlight, matchSpans(line.value, needle))
+		out = append(out, name+"  "+value)
+	}
+	for len(out) < height {
+		out = append(out, "")
+	}
+	return strings.Join(out, "\n")
+}
+
+// detailStatus 返回记录序号、滚动位置和搜索匹配数
+func (m TableModel) detailStatus() string {
+	d := m.detailState()
+	lines, _ := m.detailLines(m.detailWidth())
+	status := fmt.Sprintf("记录 %d/%d | %d 列", m.Cursor.Index()+1, len(m.OriginalRows), len(m.TableColumns))
+	if len(lines) > m.detailHeight() {
+		status += fmt.Sprintf(" | 第 %d-%d/%d 行", d.offset+1, min(d.offset+m.detailHeight(), len(lines)), len(lines))
+	}
+	if d.query != "" {
+		hits := m.detailHits(lines)
+		if len(hits) == 0 {
+			status += fmt.Sprintf(" | 搜索: %q 无匹配", d.query)
+		} else {
+			status += fmt.Sprintf(" | 搜索: %q %d/%d", d.query, (d.hit%len(hits)+len(hits))%len(hits)+1, len(hits))
+		}
+	}
+	return status
+}
+
+// detailView 全屏显示记录详情
+func (m TableModel) detailView() string {
+	var b strings.Builder
+	b.WriteString(m.Theme.Title.Render(m.Title))
+	b.WriteString("\n")
+	if m.detail.searching {
+		b.WriteString(m.Theme.Info.Render(m.detail.input.View()))
+	} else {
+		b.WriteString(m.Theme.Info.Render(m.detailStatus()))
+	}
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().MarginLeft(2).Render(m.renderDetail(m.detailWidth(), m.detailHeight())))
+	b.WriteString("\n")
+	b.WriteString(m.Theme.Help.Render(m.detailHelp()))
+	return b.String()
+}
+
+// detailHelp 返回记录详情的按键提示
+func (m TableModel) detailHelp() string {
+	if m.detail.searching {
+		return "enter 确认搜索 | esc 取消搜索"
+	}
+	pair := func(a, b key.Binding) string {
+		return joinKeyPair(a.Help().Key, b.Help().Key)
+	}
+	return fmt.Sprintf("%s 滚动 | %s 翻页 | %s 上一条/下一条 | %s 搜索 | %s 下一个/上一个匹配 | %s 返回表格",
+		pair(m.Keys.Up, m.Keys.Down), pair(m.Keys.PageUp, m.Keys.PageDown),
+		pair(m.Keys.Left, m.Keys.Right), m.Keys.Search.Help().Key,
+		pair(m.Keys.NextHit, m.Keys.PrevHit), pair(m.Keys.Detail, m.Keys.Cancel))
+}
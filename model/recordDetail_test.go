@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// detailData 列数超过一屏的测试数据，第 i 行第 j 列的值为 "r<i>c<j>"
+func detailData(rows, cols int) TableData {
+	data := TableData{}
+	for j := 1; j <= cols; j++ {
+		data.Headers = append(data.Headers, "列"+strconv.Itoa(j))
+	}
+	for i := 1; i <= rows; i++ {
+		row := make([]string, cols)
+		for j := range row {
+			row[j] = "r" + strconv.Itoa(i) + "c" + strconv.Itoa(j+1)
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	return data
+}
+
+// TestDetailLines 长的值按宽度折行而不截断，列名只在第一行显示
+func TestDetailLines(t *testing.T) {
+	long := strings.Repeat("长值", 30)
+	data := TableData{
+		Headers: []string{"ID", "说明", "大小"},
+		Columns: []ColumnSpec{{Name: "ID"}, {Name: "说明"}, {Name: "大小", Type: ColumnBytes, Format: "human"}},
+		Rows:    [][]string{{"1", long, "2KB"}},
+	}
+	m := newTestModel(t, data)
+	lines, nameWidth := m.detailLines(40)
+	if nameWidth != DisplayWidth("说明") {
+		t.Errorf("列名宽度 %d", nameWidth)
+	}
+
+	values := make(map[int]string)
+	for i, line := range lines {
+		if first := i == 0 || lines[i-1].col != line.col; (line.name != "") != first {
+			t.Errorf("第 %d 行的列名为 %q", i, line.name)
+		}
+		if DisplayWidth(line.value) > 40-nameWidth-2 {
+			t.Errorf("第 %d 行超出宽度: %q", i, line.value)
+		}
+		values[line.col] += line.value
+	}
+	want := map[int]string{0: "1", 1: long, 2: "2.0 KiB"}
+	for col, v := range want {
+		if values[col] != v {
+			t.Errorf("第 %d 列为 %q，期望 %q", col, values[col], v)
+		}
+	}
+}
+
+// TestDetailKeys 记录详情中滚动、切换记录、搜索和关闭
+func TestDetailKeys(t *testing.T) {
+	down := make([]string, 100)
+	for i := range down {
+		down[i] = "down"
+	}
+	cases := []struct {
+		name       string
+		keys       []string
+		open       bool
+		wantRecord int // 光标所在记录，从 1 开始
+		wantOffset int
+		wantStatus string
+	}{
+		{"打开", []string{"enter"}, true, 1, 0, "记录 1/3 | 60 列 | 第 1-37/60 行"},
+		{"下一条", []string{"enter", "down", "right"}, true, 2, 0, "记录 2/3"},
+		{"第一条之前", []string{"enter", "left"}, true, 1, 0, "记录 1/3"},
+		{"滚动", []string{"enter", "down", "down"}, true, 1, 2, "第 3-39/60 行"},
+		{"滚动到底", append([]string{"enter"}, down...), true, 1, 23, "第 24-60/60 行"},
+		{"搜索滚动到匹配", []string{"enter", "/", "c", "4", "0", "enter"}, true, 1, 21, `搜索: "c40" 1/1`},
+		{"下一个匹配循环", []string{"enter", "/", "列", "1", "enter", "n", "n"}, true, 1, 0, `搜索: "列1" 3/11`},
+		{"切换记录保留搜索", []string{"enter", "/", "r", "enter", "right"}, true, 2, 0, `搜索: "r" 1/60`},
+		{"取消搜索", []string{"enter", "/", "x", "esc"}, true, 1, 0, "记录 1/3"},
+		{"关闭", []string{"enter", "esc"}, false, 1, 0, ""},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, detailData(3, 60)), c.keys...)
+		if m.DetailOpen() != c.open {
+			t.Errorf("%s: 打开为 %v", c.name, m.DetailOpen())
+			continue
+		}
+		if !c.open {
+			continue
+		}
+		if record := m.Cursor.Index() + 1; record != c.wantRecord || m.detail.offset != c.wantOffset {
+			t.Errorf("%s: 记录 %d、滚动 %d，期望 %d、%d", c.name, record, m.detail.offset, c.wantRecord, c.wantOffset)
+		}
+		if status := m.detailStatus(); !strings.Contains(status, c.wantStatus) {
+			t.Errorf("%s: 状态 %q，期望包含 %q", c.name, status, c.wantStatus)
+		}
+	}
+}
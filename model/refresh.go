@@ -0,0 +1,286 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 刷新后变化单元格的高亮时长
+const changeHighlightDuration = 2 * time.Second
+
+// 刷新后变化单元格的样式
+var changedCellStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("16")).
+	Background(lipgloss.Color("114"))
+
+// cellRef 单元格位置，Row 为 AllRows 中的索引
+type cellRef struct {
+	Row, Col int
+}
+
+// refreshTickMsg 自动刷新定时
+type refreshTickMsg struct{}
+
+// clearChangesMsg 结束变化单元格的高亮
+type clearChangesMsg struct {
+	gen int
+}
+
+// Refresh 重新执行查询，完成后保留光标、排序、筛选、滚动位置和选择
+func (m *TableModel) Refresh() tea.Cmd {
+	if m.loader == nil || m.Querying {
+		return nil
+	}
+	return m.startLoad()
+}
+
+// refreshTick 按自动刷新间隔定时
+func (m TableModel) refreshTick() tea.Cmd {
+	if m.refreshInterval <= 0 || m.loader == nil {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+// handleRefreshTick 到达自动刷新时间，上一次查询尚未结束时跳过
+func (m *TableModel) handleRefreshTick() tea.Cmd {
+	var cmd tea.Cmd
+	if !m.awaitingData() {
+		cmd = m.Refresh()
+	}
+	return tea.Batch(cmd, m.refreshTick())
+}
+
+// applyRefresh 用刷新得到的数据替换数据行，列发生变化时重建表格
+func (m *TableModel) applyRefresh(data TableData, elapsed time.Duration) error {
+	specs := data.Schema()
+	if !sameColumns(specs, m.Columns) {
+		return m.reloadColumns(data, elapsed)
+	}
+
+	// 记录刷新前的状态
+	before := m.AllRows[:m.RowCount]
+	oldRows := make(map[string]table.Row, m.RowCount)
+	for i, row := range before {
+		oldRows[m.rowKey(i)] = row
+	}
+	marks := m.saveMarks()
+
+	// 正在后台执行的排序/筛选基于旧数据，结果中的索引对新数据无效
+	if m.viewJob != nil {
+		m.viewJob.cancel()
+		m.viewJob = nil
+	}
+	m.viewGen++
+	m.viewPending = false
+	m.viewUrgent = false
+
+	// 替换数据，筛选和排序条件保持不变
+	m.AllRows = nil
+	m.RowCount = 0
+	m.Selected = nil
+	m.appendRows(data.Rows)
+	m.finishSource()
+	m.QueryDuration = elapsed
+	m.RefreshedAt = time.Now()
+
+	// 按记录标识恢复选择和光标，并找出变化的单元格
+	// 记录标识有重复时无法按标识对应，改为按位置比较
+	m.restoreMarks(marks, 0)
+	byKey := len(oldRows) == len(before)
+	if byKey {
+		seen := make(map[string]bool, m.RowCount)
+		for i := range m.AllRows[:m.RowCount] {
+			k := m.rowKey(i)
+			if seen[k] {
+				byKey = false
+				break
+			}
+			seen[k] = true
+		}
+	}
+	changed := make(map[cellRef]bool)
+	for i, row := range m.AllRows[:m.RowCount] {
+		var old table.Row
+		existed := i < len(before)
+		if byKey {
+			old, existed = oldRows[m.rowKey(i)]
+		} else if existed {
+			old = before[i]
+		}
+		for c := range row {
+			if !existed || c >= len(old) || old[c] != row[c] {
+				changed[cellRef{Row: i, Col: c}] = true
+			}
+		}
+	}
+
+	m.changedCells = changed
+	m.changeGen++
+	return nil
+}
+
+// reloadColumns 列发生变化时重建表格，排序、筛选、分组、汇总项和横向滚动位置按列名重新对应，
+// 光标和选择按记录标识恢复，搜索、分组的展开状态、记录详情和预览栏保持不变
+// 对应不上的列和无法按新的列解析的筛选条件被移除，并在状态消息中列出
+func (m *TableModel) reloadColumns(data TableData, elapsed time.Duration) error {
+	type namedSort struct {
+		name string
+		desc bool
+	}
+	sorts := make([]namedSort, len(m.SortKeys))
+	for i, k := range m.SortKeys {
+		sorts[i] = namedSort{name: m.columnName(k.Column), desc: k.Desc}
+	}
+	filters := make([]string, len(m.Filters))
+	for i, f := range m.Filters {
+		filters[i] = f.String()
+	}
+	var groupNames []string
+	if m.grouping != nil {
+		for _, col := range m.grouping.columns {
+			groupNames = append(groupNames, m.columnName(col))
+		}
+	}
+	cursorColumn := m.columnName(m.ColCursor)
+	scrollColumn := m.columnName(m.ScrollOffset)
+	filterColumn := m.columnName(m.FilterColumn)
+	marks := m.saveMarks()
+	old := *m
+
+	if err := m.loadData(data, elapsed); err != nil {
+		return err
+	}
+
+	var dropped []string
+	columnIndex := func(name string) (int, bool) {
+		cols, err := columnIndexes([]string{name}, m.Columns)
+		if err != nil {
+			return 0, false
+		}
+		return cols[0], true
+	}
+	var keys []SortKey
+	for _, s := range sorts {
+		col, ok := columnIndex(s.name)
+		if !ok {
+			dropped = append(dropped, "排序列 "+s.name)
+			continue
+		}
+		keys = append(keys, SortKey{Column: col, Desc: s.desc})
+	}
+	var exprs []FilterExpr
+	for _, text := range filters {
+		expr, err := ParseFilter(text, m.columnSpecs(), -1)
+		if err != nil {
+			dropped = append(dropped, "筛选条件 ["+text+"]")
+			continue
+		}
+		exprs = append(exprs, expr)
+	}
+	var groupCols []int
+	for _, name := range groupNames {
+		col, ok := columnIndex(name)
+		if !ok {
+			dropped = append(dropped, "分组列 "+name)
+			continue
+		}
+		groupCols = append(groupCols, col)
+	}
+	var aggs []Aggregate
+	for _, agg := range old.aggregates {
+		if _, ok := columnIndex(agg.Column); !ok {
+			dropped = append(dropped, "汇总项 "+agg.Func.String()+"("+agg.Column+")")
+			continue
+		}
+		aggs = append(aggs, agg)
+	}
+	m.SortKeys = keys
+	m.Filters = exprs
+	m.aggregates = aggs
+	if len(groupCols) > 0 {
+		g := old.grouping
+		m.grouping = &groupView{columns: groupCols, expanded: g.expanded, cursor: g.cursor, offset: g.offset}
+	}
+	if col, ok := columnIndex(filterColumn); ok {
+		m.FilterColumn = col
+	}
+	m.SearchText = old.SearchText
+	m.SearchIndex = old.SearchIndex
+	m.ShowPane = old.ShowPane
+	m.PaneWidth = old.PaneWidth
+	m.PaneRenderer = old.PaneRenderer
+	m.detail = old.detail
+	m.SetSize(m.viewWidth, m.viewHeight)
+	m.ApplyFilter()
+
+	if col, ok := columnIndex(scrollColumn); ok {
+		m.ScrollOffset = col
+	}
+	if col, ok := columnIndex(cursorColumn); ok {
+		m.ColCursor = col
+	}
+	m.EnsureColumnVisible()
+	m.UpdateVisibleColumns()
+	m.restoreMarks(marks, 0)
+	m.clampDetail()
+
+	if len(dropped) > 0 {
+		m.StatusMsg = "刷新后列已变化，已移除" + strings.Join(dropped, "、")
+	}
+	return nil
+}
+
+// clearChanges 高亮时间结束后清除变化标记
+func (m TableModel) clearChanges() tea.Cmd {
+	if len(m.changedCells) == 0 {
+		return nil
+	}
+	gen := m.changeGen
+	return tea.Tick(changeHighlightDuration, func(time.Time) tea.Msg {
+		return clearChangesMsg{gen: gen}
+	})
+}
+
+// isChangedCell 判断 OriginalRows 中的单元格是否在最近一次刷新中发生变化
+func (m TableModel) isChangedCell(r, col int) bool {
+	return len(m.changedCells) > 0 && r < len(m.RowIndex) && m.changedCells[cellRef{Row: m.RowIndex[r], Col: col}]
+}
+
+// refreshStatus 返回最近一次刷新的时间
+func (m TableModel) refreshStatus() string {
+	if m.loader == nil || m.RefreshedAt.IsZero() {
+		return ""
+	}
+	status := "刷新于 " + m.RefreshedAt.Format("15:04:05")
+	if m.Querying {
+		status += fmt.Sprintf(" %s刷新中", m.Spinner.View())
+	}
+	return status
+}
+
+// sameColumns 判断两组列定义的列名是否一致
+func sameColumns(a, b []ColumnSpec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// reloadLoader 为已有数据的表格设置刷新用的查询函数
+func reloadLoader(load Loader, cfg config) *queryLoader {
+	return &queryLoader{load: load, ctx: context.Background(), cfg: cfg}
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// loadedModel 构建查询已返回 data 的表格
+func loadedModel(t *testing.T, data TableData) TableModel {
+	t.Helper()
+	m := NewTableModelFromLoader(context.Background(), func(context.Context) (TableData, error) {
+		return data, nil
+	})
+	m.SetSize(120, 40)
+	refreshWith(t, &m, data)
+	return m
+}
+
+// refreshWith 执行一次查询并以 data 作为结果
+func refreshWith(t *testing.T, m *TableModel, data TableData) {
+	t.Helper()
+	m.startLoad()
+	m.handleDataLoaded(dataLoadedMsg{gen: m.loadGen, data: data})
+	finishViewJob(t, m)
+	if m.LoadErr != nil {
+		t.Fatal(m.LoadErr)
+	}
+}
+
+// TestReloadColumnsKeepsState 刷新后列发生变化时，按列名重新对应排序、筛选、分组等状态
+func TestReloadColumnsKeepsState(t *testing.T) {
+	before := TableData{
+		Headers: []string{"ID", "城市", "数量", "备注"},
+		Keys:    []string{"ID"},
+		Rows: [][]string{
+			{"1", "北京", "10", "a"}, {"2", "上海", "20", "b"},
+			{"3", "北京", "30", "c"}, {"4", "深圳", "40", "d"},
+		},
+	}
+	after := TableData{
+		Headers: []string{"新列", "ID", "城市", "数量"},
+		Keys:    []string{"ID"},
+		Rows: [][]string{
+			{"x", "4", "深圳", "40"}, {"x", "3", "北京", "35"},
+			{"x", "2", "上海", "20"}, {"x", "1", "北京", "10"},
+		},
+	}
+	m := loadedModel(t, before)
+	m.SetSort(SortKey{Column: 2, Desc: true})
+	for _, f := range []string{"城市 != 深圳", "备注 != z"} {
+		if err := m.AddFilter(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.SetGroupBy("城市"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetAggregates(Aggregate{Column: "数量"}, Aggregate{Column: "备注", Func: AggMax}); err != nil {
+		t.Fatal(err)
+	}
+	m.grouping.expanded["北京"] = true
+	m.SetSearch("北京")
+	m.ShowPane = true
+	m.MoveColumnCursor(2)
+	m.Selected = map[int]bool{2: true} // ID 3
+
+	refreshWith(t, &m, after)
+
+	checks := []struct {
+		name string
+		ok   bool
+	}{
+		{"排序列", len(m.SortKeys) == 1 && m.SortKeys[0] == SortKey{Column: 3, Desc: true}},
+		{"筛选条件", len(m.Filters) == 1 && m.Filters[0].String() == "城市 != 深圳"},
+		{"显示行", len(m.OriginalRows) == 3 && m.OriginalRows[0][1] == "3"},
+		{"分组列", m.grouping != nil && len(m.grouping.columns) == 1 && m.grouping.columns[0] == 2},
+		{"展开状态", m.grouping != nil && m.grouping.expanded["北京"]},
+		{"汇总项", len(m.aggregates) == 1 && m.grouping != nil && m.grouping.groups[0].aggs[0] == "45"},
+		{"搜索", m.SearchText == "北京" && len(m.SearchMatches) == 2},
+		{"预览栏", m.ShowPane},
+		{"光标列", m.ColCursor == 3},
+		{"选择", len(m.Selected) == 1 && m.Selected[1]},
+		{"状态消息", strings.Contains(m.StatusMsg, "备注 != z") && strings.Contains(m.StatusMsg, "最大(备注)")},
+	}
+	for _, c := range checks {
+		if !c.ok {
+			t.Errorf("刷新后%s不正确", c.name)
+		}
+	}
+}
+
+// TestRefreshChangedCells 刷新后标出变化的单元格，记录标识重复时按位置比较
+func TestRefreshChangedCells(t *testing.T) {
+	cases := []struct {
+		name   string
+		keys   []string
+		before [][]string
+		after  [][]string
+		want   []cellRef
+	}{
+		{
+			name:   "按主键对应",
+			keys:   []string{"ID"},
+			before: [][]string{{"1", "a"}, {"2", "b"}},
+			after:  [][]string{{"2", "b"}, {"1", "c"}, {"3", "d"}},
+			want:   []cellRef{{Row: 1, Col: 1}, {Row: 2, Col: 0}, {Row: 2, Col: 1}},
+		},
+		{
+			name:   "主键重复时按位置",
+			keys:   []string{"ID"},
+			before: [][]string{{"1", "a"}, {"1", "b"}},
+			after:  [][]string{{"1", "a"}, {"1", "c"}},
+			want:   []cellRef{{Row: 1, Col: 1}},
+		},
+		{
+			name:   "没有主键",
+			before: [][]string{{"1", "a"}, {"2", "b"}},
+			after:  [][]string{{"2", "b"}, {"1", "a"}},
+			want:   []cellRef{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 1, Col: 0}, {Row: 1, Col: 1}},
+		},
+	}
+	for _, c := range cases {
+		headers := []string{"ID", "值"}
+		m := loadedModel(t, TableData{Headers: headers, Keys: c.keys, Rows: c.before})
+		refreshWith(t, &m, TableData{Headers: headers, Keys: c.keys, Rows: c.after})
+		if len(m.changedCells) != len(c.want) {
+			t.Errorf("%s: 变化的单元格为 %v，期望 %v", c.name, m.changedCells, c.want)
+			continue
+		}
+		for _, ref := range c.want {
+			if !m.changedCells[ref] {
+				t.Errorf("%s: %v 未标记为变化", c.name, ref)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowCursor 行光标，只记录位置和行数而不持有数据行，移动的开销与行数无关
+type RowCursor struct {
+	index   int
+	length  int
+	focused bool
+}
+
+// 行光标的翻页按键，上下移动一行由 KeyMap 中的 Up/Down 负责
+var rowCursorKeys = struct {
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	GotoTop      key.Binding
+	GotoBottom   key.Binding
+}{
+	PageUp:       key.NewBinding(key.WithKeys("pgup")),
+	PageDown:     key.NewBinding(key.WithKeys("pgdown")),
+	HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
+	HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
+	GotoTop:      key.NewBinding(key.WithKeys("home")),
+	GotoBottom:   key.NewBinding(key.WithKeys("end")),
+}
+
+// Index 返回光标所在行
+func (c RowCursor) Index() int {
+	return c.index
+}
+
+// Len 返回行数
+func (c RowCursor) Len() int {
+	return c.length
+}
+
+// SetLen 设置行数，光标超出范围时移到最后一行
+func (c *RowCursor) SetLen(n int) {
+	c.length = n
+	c.Set(c.index)
+}
+
+// Set 将光标移动到指定行，超出范围时取最近的有效行
+func (c *RowCursor) Set(i int) {
+	if i >= c.length {
+		i = c.length - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	c.index = i
+}
+
+// Move 将光标移动 delta 行，正数向下、负数向上
+func (c *RowCursor) Move(delta int) {
+	c.Set(c.index + delta)
+}
+
+// Focus 获得焦点
+func (c *RowCursor) Focus() {
+	c.focused = true
+}
+
+// Blur 失去焦点
+func (c *RowCursor) Blur() {
+	c.focused = false
+}
+
+// Focused 返回是否拥有焦点
+func (c RowCursor) Focused() bool {
+	return c.focused
+}
+
+// Update 处理翻页按键，page 为一页的行数，返回按键是否被处理
+func (c *RowCursor) Update(msg tea.KeyMsg, page int) bool {
+	if !c.focused {
+		return false
+	}
+	switch {
+	case key.Matches(msg, rowCursorKeys.PageUp):
+		c.Move(-page)
+	case key.Matches(msg, rowCursorKeys.PageDown):
+		c.Move(page)
+	case key.Matches(msg, rowCursorKeys.HalfPageUp):
+		c.Move(-page / 2)
+	case key.Matches(msg, rowCursorKeys.HalfPageDown):
+		c.Move(page / 2)
+	case key.Matches(msg, rowCursorKeys.GotoTop):
+		c.Set(0)
+	case key.Matches(msg, rowCursorKeys.GotoBottom):
+		c.Set(c.length - 1)
+	default:
+		return false
+	}
+	return true
+}
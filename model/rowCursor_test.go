@@ -0,0 +1,93 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestRowCursor 翻页按键按页高移动光标，并限制在有效范围内
+func TestRowCursor(t *testing.T) {
+	cases := []struct {
+		name    string
+		index   int
+		length  int
+		key     tea.KeyType
+		blurred bool
+		want    int
+		handled bool
+	}{
+		{"下一页", 0, 100, tea.KeyPgDown, false, 10, true},
+		{"下一页到末尾", 95, 100, tea.KeyPgDown, false, 99, true},
+		{"上一页到开头", 5, 100, tea.KeyPgUp, false, 0, true},
+		{"下半页", 10, 100, tea.KeyCtrlD, false, 15, true},
+		{"上半页", 10, 100, tea.KeyCtrlU, false, 5, true},
+		{"开头", 50, 100, tea.KeyHome, false, 0, true},
+		{"末尾", 50, 100, tea.KeyEnd, false, 99, true},
+		{"没有数据", 0, 0, tea.KeyEnd, false, 0, true},
+		{"其他按键", 50, 100, tea.KeyUp, false, 50, false},
+		{"没有焦点", 50, 100, tea.KeyEnd, true, 50, false},
+	}
+	for _, c := range cases {
+		var cur RowCursor
+		cur.SetLen(c.length)
+		cur.Set(c.index)
+		if !c.blurred {
+			cur.Focus()
+		}
+		handled := cur.Update(tea.KeyMsg{Type: c.key}, 10)
+		if handled != c.handled || cur.Index() != c.want {
+			t.Errorf("%s: 光标 %d（处理 %v），期望 %d（处理 %v）", c.name, cur.Index(), handled, c.want, c.handled)
+		}
+	}
+}
+
+// TestRowCursorSetLen 行数减少时光标移到最后一行
+func TestRowCursorSetLen(t *testing.T) {
+	cases := []struct {
+		index, length, newLen, want int
+	}{
+		{5, 10, 20, 5},
+		{8, 10, 3, 2},
+		{8, 10, 0, 0},
+	}
+	for _, c := range cases {
+		var cur RowCursor
+		cur.SetLen(c.length)
+		cur.Set(c.index)
+		cur.SetLen(c.newLen)
+		if cur.Index() != c.want {
+			t.Errorf("从 %d 行中的第 %d 行改为 %d 行: 光标 %d，期望 %d", c.length, c.index, c.newLen, cur.Index(), c.want)
+		}
+	}
+}
+
+// TestVirtualRows 只渲染可见的行，跳到末尾后显示最后一行
+func TestVirtualRows(t *testing.T) {
+	rows := make([][]string, 20000)
+	for i := range rows {
+		rows[i] = []string{"行" + strconv.Itoa(i+1)}
+	}
+	m := newTestModel(t, TableData{Headers: []string{"名称"}, Rows: rows})
+	cases := []struct {
+		key    tea.KeyType
+		shown  string
+		hidden string
+	}{
+		{tea.KeyEnd, "行20000", "行1 "},
+		{tea.KeyHome, "行1 ", "行20000"},
+	}
+	for _, c := range cases {
+		tm, _ := m.Update(tea.KeyMsg{Type: c.key})
+		m = tm.(TableModel)
+		view := m.View()
+		if n := strings.Count(view, "\n") + 1; n > 40 {
+			t.Errorf("%v: 渲染了 %d 行，超过窗口高度", c.key, n)
+		}
+		if !strings.Contains(view, c.shown) || strings.Contains(view, c.hidden) {
+			t.Errorf("%v: 应显示 %q 而不显示 %q:\n%s", c.key, c.shown, c.hidden, view)
+		}
+	}
+}
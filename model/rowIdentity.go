@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// rowMarks 数据行被替换前光标所在记录和已选记录的标识
+type rowMarks struct {
+	cursor   string          // 光标所在记录，空字符串表示不恢复光标
+	screen   int             // 光标在屏幕上的行
+	selected map[string]bool // 已选记录
+}
+
+// resolveKeyColumns 按列名查找数据源声明的主键列
+func (m *TableModel) resolveKeyColumns() error {
+	cols, err := columnIndexes(m.keyNames, m.Columns)
+	if err != nil {
+		err = fmt.Errorf("主键%w", err)
+	}
+	m.keyColumns = cols
+	return err
+}
+
+// recordKey 返回数据行的记录标识，有主键列时由主键列的值组成，否则为行的索引
+func recordKey(row table.Row, keyColumns []int, idx int) string {
+	if len(keyColumns) == 0 {
+		return strconv.Itoa(idx)
+	}
+	values := make([]string, len(keyColumns))
+	for i, col := range keyColumns {
+		values[i] = cellAt(row, col)
+	}
+	return strings.Join(values, "\x1f")
+}
+
+// rowKey 返回 AllRows 中第 idx 行的记录标识
+func (m TableModel) rowKey(idx int) string {
+	return recordKey(m.AllRows[idx], m.keyColumns, idx)
+}
+
+// saveMarks 记录光标所在记录和已选记录
+func (m TableModel) saveMarks() rowMarks {
+	marks := rowMarks{
+		screen:   m.Cursor.Index() - m.RowOffset,
+		selected: make(map[string]bool, len(m.Selected)),
+	}
+	if idx, ok := m.cursorRowIndex(); ok {
+		marks.cursor = m.rowKey(idx)
+	}
+	for idx := range m.Selected {
+		marks.selected[m.rowKey(idx)] = true
+	}
+	return marks
+}
+
+// restoreMarks 在 AllRows 中从 from 开始的数据行里按标识恢复选择和光标
+func (m *TableModel) restoreMarks(marks rowMarks, from int) {
+	if marks.cursor == "" && len(marks.selected) == 0 {
+		return
+	}
+	for i := from; i < m.RowCount; i++ {
+		key := m.rowKey(i)
+		if marks.selected[key] {
+			if m.Selected == nil {
+				m.Selected = make(map[int]bool)
+			}
+			m.Selected[i] = true
+		}
+		if marks.cursor != "" && key == marks.cursor {
+			m.followRow = i
+			m.followScreen = marks.screen
+		}
+	}
+
+	// 后台排序/筛选完成时再定位光标
+	if !m.viewPending {
+		m.placeFollowRow()
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestRecordKey 有主键列时由主键列的值组成标识，否则使用行的索引
+func TestRecordKey(t *testing.T) {
+	row := table.Row{"1", "北京", "a"}
+	cases := []struct {
+		name string
+		keys []int
+		want string
+	}{
+		{"没有主键", nil, "7"},
+		{"单列主键", []int{1}, "北京"},
+		{"联合主键", []int{1, 0}, "北京\x1f1"},
+		{"主键列超出行长度", []int{0, 5}, "1\x1f"},
+	}
+	for _, c := range cases {
+		if got := recordKey(row, c.keys, 7); got != c.want {
+			t.Errorf("%s: 标识 %q，期望 %q", c.name, got, c.want)
+		}
+	}
+}
+
+// TestResolveKeyColumns 主键列按列名查找，不区分大小写，不存在时报错
+func TestResolveKeyColumns(t *testing.T) {
+	cases := []struct {
+		keys    []string
+		want    []int
+		wantErr string
+	}{
+		{[]string{"id"}, []int{0}, ""},
+		{[]string{"城市", "ID"}, []int{1, 0}, ""},
+		{[]string{"编号"}, nil, `主键列 "编号" 不存在`},
+	}
+	for _, c := range cases {
+		data := TableData{Headers: []string{"ID", "城市"}, Keys: c.keys, Rows: [][]string{{"1", "北京"}}}
+		m, err := NewTableModelFromSource(data)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("%v: 错误 %v，期望 %q", c.keys, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(m.keyColumns, c.want) {
+			t.Errorf("%v: 主键列 %v（%v），期望 %v", c.keys, m.keyColumns, err, c.want)
+		}
+	}
+}
+
+// TestMarksFollowRecord 刷新后按主键恢复光标和选择，而不是停留在原来的位置
+func TestMarksFollowRecord(t *testing.T) {
+	before := TableData{
+		Headers: []string{"ID", "名称"},
+		Keys:    []string{"ID"},
+		Rows:    [][]string{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}},
+	}
+	cases := []struct {
+		name         string
+		rows         [][]string
+		wantCursor   string // 光标所在记录的 ID
+		wantSelected []string
+	}{
+		{"顺序变化", [][]string{{"4", "d"}, {"3", "c"}, {"2", "b"}, {"1", "a"}}, "3", []string{"2"}},
+		{"插入新行", [][]string{{"0", "z"}, {"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}}, "3", []string{"2"}},
+		{"已选记录被删除", [][]string{{"1", "a"}, {"3", "c"}, {"4", "d"}}, "3", nil},
+		{"光标所在记录被删除", [][]string{{"2", "b"}, {"4", "d"}}, "", []string{"2"}},
+	}
+	for _, c := range cases {
+		m := press(t, loadedModel(t, before), "down", "space")
+		refreshWith(t, &m, TableData{Headers: before.Headers, Keys: before.Keys, Rows: c.rows})
+
+		var selected []string
+		for _, row := range m.SelectedRows() {
+			selected = append(selected, row.Values[0])
+		}
+		if len(m.Selected) == 0 {
+			selected = nil
+		}
+		if !slices.Equal(selected, c.wantSelected) {
+			t.Errorf("%s: 选中 %v，期望 %v", c.name, selected, c.wantSelected)
+		}
+		if c.wantCursor == "" {
+			continue
+		}
+		if got := m.SelectedCell(); got != c.wantCursor {
+			t.Errorf("%s: 光标在 %s，期望 %s", c.name, got, c.wantCursor)
+		}
+	}
+}
+
+// reversingSource 带主键的数据源，按第一个排序键倒序时倒序返回数据
+type reversingSource struct {
+	*countingSource
+	rows [][]string // 默认顺序的数据
+}
+
+func (s reversingSource) KeyColumns() []string { return s.data.Keys }
+
+func (s reversingSource) Sort(keys []SortKey) error {
+	s.data.Rows = slices.Clone(s.rows)
+	if len(keys) > 0 && keys[0].Desc {
+		slices.Reverse(s.data.Rows)
+	}
+	return nil
+}
+
+// TestMarksAfterSourceReload 排序下推后从头读取，按主键恢复光标和选择
+func TestMarksAfterSourceReload(t *testing.T) {
+	rows := [][]string{{"1"}, {"2"}, {"3"}, {"4"}}
+	src := reversingSource{&countingSource{data: TableData{Headers: []string{"ID"}, Keys: []string{"ID"}}}, rows}
+	m := newSourceTestModel(t, src)
+	for _, k := range []string{"down", "space", "s", "s"} {
+		m = sourceUpdate(t, m, keyMsg(k))
+	}
+
+	var shown []string
+	for _, row := range m.OriginalRows {
+		shown = append(shown, row[0])
+	}
+	if !slices.Equal(shown, []string{"4", "3", "2", "1"}) {
+		t.Fatalf("数据源没有倒序返回: %v", shown)
+	}
+	if got := m.SelectedCell(); got != "3" {
+		t.Errorf("光标在 %s，期望 3", got)
+	}
+	if rows := m.SelectedRows(); len(m.Selected) != 1 || rows[0].Values[0] != "2" {
+		t.Errorf("选中 %v，期望 ID 2", rows)
+	}
+}
@@ -0,0 +1,409 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 搜索命中高亮样式
+var (
+	searchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("16")).
+				Background(lipgloss.Color("220"))
+
+	currentSearchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("16")).
+				Background(lipgloss.Color("208")).
+				Bold(true)
+
+	// 搜索栏标签样式
+	searchBarStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220")).
+			Bold(true).
+			MarginLeft(2)
+)
+
+// SearchMatch 搜索命中的单元格位置
+type SearchMatch struct {
+	Row int // 在当前数据行（OriginalRows）中的索引
+	Col int // 列索引
+}
+
+// span 单元格文本中需要高亮的区间（按 rune 计）
+type span struct {
+	start, end int
+}
+
+// searchDebounce 数据量大时输入停顿多久后开始搜索
+const searchDebounce = 150 * time.Millisecond
+
+// searchJob 正在后台执行的搜索
+type searchJob struct {
+	gen    int
+	cancel context.CancelFunc
+}
+
+// searchInput 搜索所需的数据快照，计算过程中只读
+type searchInput struct {
+	text    string
+	rows    []table.Row
+	index   []int // rows 中每一行在 AllRows 中的索引
+	version int   // 快照对应的显示行版本
+	specs   []ColumnSpec
+}
+
+// searchDoneMsg 后台搜索完成
+type searchDoneMsg struct {
+	gen     int
+	in      searchInput
+	matches []SearchMatch
+	err     error
+}
+
+// StartSearch 打开搜索栏
+func (m *TableModel) StartSearch() {
+	m.Searching = true
+	m.SearchInput.Reset()
+	m.SearchInput.SetValue(m.SearchText)
+	m.SearchInput.CursorEnd()
+	m.SearchInput.Focus()
+}
+
+// SetSearch 设置搜索文本，重新查找全部命中并跳转到光标之后的第一个命中
+// 数据量大时在后台查找，连续输入时只执行最后一次
+func (m *TableModel) SetSearch(text string) {
+	m.SearchText = text
+	m.searchJump = true
+	if text != "" && len(m.OriginalRows) >= asyncRowThreshold {
+		m.SearchMatches = nil
+		m.SearchIndex = 0
+		m.searchPending = true
+		m.searchUrgent = true
+		return
+	}
+	m.cancelSearchJob()
+	matches, _ := findMatches(context.Background(), m.searchInput())
+	m.setMatches(matches)
+}
+
+// ClearSearch 清除搜索及高亮
+func (m *TableModel) ClearSearch() {
+	m.cancelSearchJob()
+	m.SearchText = ""
+	m.SearchMatches = nil
+	m.SearchIndex = 0
+	m.searchJump = false
+}
+
+// NextMatch 跳转到下一个（forward 为 false 时为上一个）命中，首尾循环
+func (m *TableModel) NextMatch(forward bool) {
+	n := len(m.SearchMatches)
+	if n == 0 {
+		return
+	}
+	if forward {
+		m.gotoMatch((m.SearchIndex + 1) % n)
+	} else {
+		m.gotoMatch((m.SearchIndex - 1 + n) % n)
+	}
+}
+
+// gotoMatch 将行光标和列光标移动到指定命中，必要时滚动
+func (m *TableModel) gotoMatch(i int) {
+	m.SearchIndex = i
+	match := m.SearchMatches[i]
+	m.Cursor.Set(match.Row)
+	m.MoveColumnCursor(match.Col)
+	m.EnsureCursorVisible()
+}
+
+// setMatches 替换命中列表，新输入的搜索跳转到光标之后的第一个命中，否则保留当前命中的序号
+func (m *TableModel) setMatches(matches []SearchMatch) {
+	index := m.SearchIndex
+	m.SearchMatches = matches
+	m.SearchIndex = 0
+	if !m.searchJump {
+		if index < len(matches) {
+			m.SearchIndex = index
+		}
+		return
+	}
+	m.searchJump = false
+	if len(matches) == 0 {
+		return
+	}
+
+	// 从当前光标位置开始寻找第一个命中
+	cursor := m.Cursor.Index()
+	for i, match := range matches {
+		if match.Row > cursor || (match.Row == cursor && match.Col >= m.ColCursor) {
+			m.gotoMatch(i)
+			return
+		}
+	}
+	m.gotoMatch(0)
+}
+
+// searchInput 为当前显示行生成搜索快照
+func (m TableModel) searchInput() searchInput {
+	return searchInput{
+		text:    m.SearchText,
+		rows:    m.OriginalRows,
+		index:   m.RowIndex,
+		version: m.rowsVersion,
+		specs:   m.columnSpecs(),
+	}
+}
+
+// findMatches 在全部列（包括滚动到可见区域之外的列）中查找命中，按行优先排列
+func findMatches(ctx context.Context, in searchInput) ([]SearchMatch, error) {
+	if in.text == "" {
+		return nil, nil
+	}
+
+	needle := []rune(strings.ToLower(in.text))
+	var matches []SearchMatch
+	for r, row := range in.rows {
+		if r%viewCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		for c, cell := range row {
+			if containsFold(columnSpecAt(in.specs, c).FormatCell(cell), needle) {
+				matches = append(matches, SearchMatch{Row: r, Col: c})
+			}
+		}
+	}
+	return matches, nil
+}
+
+// refreshSearch 数据行变化后重新计算命中，保留搜索文本
+func (m *TableModel) refreshSearch() {
+	if m.SearchText == "" {
+		return
+	}
+	if len(m.OriginalRows) >= asyncRowThreshold {
+		m.searchPending = true
+		return
+	}
+	m.cancelSearchJob()
+	matches, _ := findMatches(context.Background(), m.searchInput())
+	m.setMatches(matches)
+}
+
+// startSearchJob 在后台执行待处理的搜索
+// 新的输入取代正在执行的搜索并等待输入停顿；仅因显示行变化而需要重新搜索时等当前搜索完成后再执行
+func (m *TableModel) startSearchJob() tea.Cmd {
+	if !m.searchPending {
+		return nil
+	}
+	if m.searchJob != nil {
+		if !m.searchUrgent {
+			return nil
+		}
+		m.searchJob.cancel()
+	}
+	var delay time.Duration
+	if m.searchUrgent {
+		delay = searchDebounce
+	}
+	m.searchPending = false
+	m.searchUrgent = false
+	m.searchGen++
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &searchJob{gen: m.searchGen, cancel: cancel}
+	m.searchJob = job
+
+	in := m.searchInput()
+	work := func() tea.Msg {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return searchDoneMsg{gen: job.gen, err: ctx.Err()}
+		case <-timer.C:
+		}
+		matches, err := findMatches(ctx, in)
+		return searchDoneMsg{gen: job.gen, in: in, matches: matches, err: err}
+	}
+	return tea.Batch(work, m.Spinner.Tick)
+}
+
+// handleSearchDone 应用后台搜索结果，丢弃已被取代或取消的结果
+// 搜索期间显示行发生变化时，按记录换算到新的位置，新出现的行由随后的搜索补上
+func (m *TableModel) handleSearchDone(msg searchDoneMsg) {
+	if m.searchJob == nil || msg.gen != m.searchJob.gen {
+		return
+	}
+	m.searchJob.cancel()
+	m.searchJob = nil
+	if msg.err != nil || msg.in.text != m.SearchText {
+		return
+	}
+	matches := msg.matches
+	if msg.in.version != m.rowsVersion {
+		matches = m.remapMatches(matches, msg.in.index)
+	}
+	m.setMatches(matches)
+}
+
+// remapMatches 将按旧显示行计算的命中换算到当前显示行，已不再显示的行被丢弃
+func (m TableModel) remapMatches(matches []SearchMatch, index []int) []SearchMatch {
+	pos := make([]int, len(m.AllRows))
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i, idx := range m.RowIndex {
+		if idx < len(pos) {
+			pos[idx] = i
+		}
+	}
+
+	var remapped []SearchMatch
+	for _, match := range matches {
+		if match.Row >= len(index) || index[match.Row] >= len(pos) {
+			continue
+		}
+		if r := pos[index[match.Row]]; r >= 0 {
+			remapped = append(remapped, SearchMatch{Row: r, Col: match.Col})
+		}
+	}
+	sort.SliceStable(remapped, func(i, j int) bool {
+		if remapped[i].Row != remapped[j].Row {
+			return remapped[i].Row < remapped[j].Row
+		}
+		return remapped[i].Col < remapped[j].Col
+	})
+	return remapped
+}
+
+// cancelSearchJob 取消后台搜索和待执行的搜索
+func (m *TableModel) cancelSearchJob() {
+	if m.searchJob != nil {
+		m.searchJob.cancel()
+		m.searchJob = nil
+	}
+	m.searchPending = false
+	m.searchUrgent = false
+}
+
+// searchRunning 是否有搜索正在后台执行或等待执行
+func (m TableModel) searchRunning() bool {
+	return m.searchJob != nil || m.searchPending
+}
+
+// isCurrentMatch 判断单元格是否为当前命中
+func (m TableModel) isCurrentMatch(row, col int) bool {
+	if m.SearchIndex >= len(m.SearchMatches) {
+		return false
+	}
+	match := m.SearchMatches[m.SearchIndex]
+	return match.Row == row && match.Col == col
+}
+
+// searchStatus 返回命中计数，如 3/17
+func (m TableModel) searchStatus() string {
+	if m.searchRunning() {
+		return m.Spinner.View() + "搜索中"
+	}
+	if len(m.SearchMatches) == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d", m.SearchIndex+1, len(m.SearchMatches))
+}
+
+// containsFold 按与 matchSpans 相同的规则判断文本中是否存在命中，不分配内存
+func containsFold(text string, needle []rune) bool {
+	if len(needle) == 0 {
+		return false
+	}
+	for i := 0; i < len(text); {
+		j, k := i, 0
+		for k < len(needle) && j < len(text) {
+			r, size := utf8.DecodeRuneInString(text[j:])
+			if unicode.ToLower(r) != needle[k] {
+				break
+			}
+			j += size
+			k++
+		}
+		if k == len(needle) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return false
+}
+
+// matchSpans 不区分大小写地查找 needle 在文本中的全部出现位置
+// 按 rune 比较，保证区间与原文字符一一对应
+func matchSpans(text string, needle []rune) []span {
+	if len(needle) == 0 {
+		return nil
+	}
+	runes := []rune(text)
+	var spans []span
+	for i := 0; i+len(needle) <= len(runes); {
+		matched := true
+		for j, r := range needle {
+			if unicode.ToLower(runes[i+j]) != r {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			spans = append(spans, span{start: i, end: i + len(needle)})
+			i += len(needle)
+			continue
+		}
+		i++
+	}
+	return spans
+}
+
+// renderHighlighted 渲染带高亮区间的单元格，按对齐方式补齐宽度并保留单元格内边距
+func renderHighlighted(text string, width int, align lipgloss.Position, base, highlight lipgloss.Style, spans []span) string {
+	text = TruncateWidth(text, width)
+	runes := []rune(text)
+
+	var content strings.Builder
+	last := 0
+	for _, sp := range spans {
+		if sp.start >= len(runes) {
+			break
+		}
+		end := sp.end
+		if end > len(runes) {
+			end = len(runes)
+		}
+		content.WriteString(base.UnsetPadding().Render(string(runes[last:sp.start])))
+		content.WriteString(highlight.UnsetPadding().Render(string(runes[sp.start:end])))
+		last = end
+	}
+	content.WriteString(base.UnsetPadding().Render(string(runes[last:])))
+
+	// 计算对齐所需的左右空白
+	gap := width - DisplayWidth(text)
+	if gap < 0 {
+		gap = 0
+	}
+	left := int(float64(gap) * float64(align))
+	right := gap - left
+
+	pad := base.UnsetPadding()
+	return pad.Render(strings.Repeat(" ", base.GetPaddingLeft()+left)) +
+		content.String() +
+		pad.Render(strings.Repeat(" ", right+base.GetPaddingRight()))
+}
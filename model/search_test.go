@@ -0,0 +1,68 @@
+package model
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestMatchSpans 不区分大小写，按 rune 给出区间，与 containsFold 的判断一致
+func TestMatchSpans(t *testing.T) {
+	cases := []struct {
+		text, needle string
+		want         []span
+	}{
+		{"Hello hello", "hello", []span{{0, 5}, {6, 11}}},
+		{"北京北京市", "北京", []span{{0, 2}, {2, 4}}},
+		{"aaa", "aa", []span{{0, 2}}},
+		{"abc", "d", nil},
+		{"abc", "", nil},
+	}
+	for _, c := range cases {
+		needle := []rune(strings.ToLower(c.needle))
+		if got := matchSpans(c.text, needle); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("matchSpans(%q, %q) = %v，期望 %v", c.text, c.needle, got, c.want)
+		}
+		if got := containsFold(c.text, needle); got != (len(c.want) > 0) {
+			t.Errorf("containsFold(%q, %q) = %v", c.text, c.needle, got)
+		}
+	}
+}
+
+// TestFindMatches 在全部列中按显示文本查找，按行优先排列
+func TestFindMatches(t *testing.T) {
+	rows := []table.Row{{"1", "Alice", "1KB"}, {"2", "bob", "2KB"}, {"3", "ALICE", "1B"}}
+	specs := []ColumnSpec{{Type: ColumnInt}, {Type: ColumnString}, {Type: ColumnBytes, Format: "human"}}
+	cases := []struct {
+		text string
+		want []SearchMatch
+	}{
+		{"alice", []SearchMatch{{0, 1}, {2, 1}}},
+		{"1", []SearchMatch{{0, 0}, {0, 2}, {2, 2}}},
+		{"KiB", []SearchMatch{{0, 2}, {1, 2}}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		got, err := findMatches(context.Background(), searchInput{text: c.text, rows: rows, specs: specs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q: 命中 %v，期望 %v", c.text, got, c.want)
+		}
+	}
+}
+
+// TestSearchBarTheme 搜索栏标签使用 Theme 中的样式
+func TestSearchBarTheme(t *testing.T) {
+	theme := DefaultTheme()
+	theme.SearchBar = theme.SearchBar.Transform(func(s string) string { return "<" + s + ">" })
+	m := newTestModel(t, TableData{Headers: []string{"名称"}, Rows: [][]string{{"a"}}}, WithTheme(theme))
+	m.StartSearch()
+	if view := m.View(); !strings.Contains(view, "<搜索: >") {
+		t.Errorf("搜索栏没有使用 Theme.SearchBar\n%s", view)
+	}
+}
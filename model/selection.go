@@ -0,0 +1,302 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 已选中行的样式
+var pickedRowStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("229")).
+	Background(lipgloss.Color("237"))
+
+// 导出、复制时等待选择作用范围的操作
+const (
+	scopeNone = iota
+	scopeExport
+	scopeCopy
+	scopeGroupExport
+)
+
+// BulkAction 作用于选中行的批量操作
+type BulkAction struct {
+	Name string                         // 操作名称，显示在帮助和状态栏中
+	Key  string                         // 触发按键，如 "D"、"ctrl+d"
+	Run  func(rows []table.Row) tea.Cmd // 操作函数，参数为选中的行（无多选时为光标所在行）
+}
+
+// RegisterBulkAction 注册批量操作，按键与内置快捷键冲突时内置快捷键优先
+func (m *TableModel) RegisterBulkAction(action BulkAction) {
+	m.BulkActions = append(m.BulkActions, action)
+}
+
+// runBulkAction 查找与按键匹配的批量操作并执行
+func (m *TableModel) runBulkAction(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.ReadOnly {
+		return nil, false
+	}
+	for _, action := range m.BulkActions {
+		if !key.Matches(msg, key.NewBinding(key.WithKeys(action.Key))) {
+			continue
+		}
+		rows := m.selectedTableRows()
+		if len(rows) == 0 {
+			return nil, true
+		}
+		m.StatusMsg = fmt.Sprintf("已对 %d 行执行: %s", len(rows), action.Name)
+		if action.Run == nil {
+			return nil, true
+		}
+		return action.Run(rows), true
+	}
+	return nil, false
+}
+
+// bulkActionHelp 返回已注册批量操作的帮助绑定
+func (m TableModel) bulkActionHelp() []key.Binding {
+	if m.ReadOnly {
+		return nil
+	}
+	bindings := make([]key.Binding, 0, len(m.BulkActions))
+	for _, action := range m.BulkActions {
+		bindings = append(bindings, key.NewBinding(
+			key.WithKeys(action.Key),
+			key.WithHelp(action.Key, action.Name),
+		))
+	}
+	return bindings
+}
+
+// ToggleSelection 切换光标所在行的选中状态
+func (m *TableModel) ToggleSelection() {
+	idx, ok := m.cursorRowIndex()
+	if !ok {
+		return
+	}
+	if m.Selected == nil {
+		m.Selected = make(map[int]bool)
+	}
+	if m.Selected[idx] {
+		delete(m.Selected, idx)
+	} else {
+		m.Selected[idx] = true
+	}
+}
+
+// SelectedRows 返回选中的行（按原始顺序），没有多选时返回光标所在行
+func (m TableModel) SelectedRows() []SelectedRow {
+	if m.RowCount == 0 {
+		return nil
+	}
+	if len(m.Selected) > 0 {
+		indices := make([]int, 0, len(m.Selected))
+		for idx := range m.Selected {
+			indices = append(indices, idx)
+		}
+		sort.Ints(indices)
+
+		rows := make([]SelectedRow, 0, len(indices))
+		for _, idx := range indices {
+			rows = append(rows, SelectedRow{Index: idx, Values: m.AllRows[idx]})
+		}
+		return rows
+	}
+	if idx, ok := m.cursorRowIndex(); ok {
+		return []SelectedRow{{Index: idx, Values: m.AllRows[idx]}}
+	}
+	return nil
+}
+
+// displayedRows 返回当前显示的全部数据行（按显示顺序）
+func (m TableModel) displayedRows() []SelectedRow {
+	if m.RowCount == 0 {
+		return nil
+	}
+	rows := make([]SelectedRow, len(m.OriginalRows))
+	for i, row := range m.OriginalRows {
+		rows[i] = SelectedRow{Index: m.RowIndex[i], Values: row}
+	}
+	return rows
+}
+
+// cursorRowIndex 返回光标所在行在 AllRows 中的索引
+func (m TableModel) cursorRowIndex() (int, bool) {
+	cursor := m.Cursor.Index()
+	if m.RowCount == 0 || cursor >= len(m.RowIndex) {
+		return 0, false
+	}
+	return m.RowIndex[cursor], true
+}
+
+// isRowSelected 判断 OriginalRows 中的第 r 行是否被选中
+func (m TableModel) isRowSelected(r int) bool {
+	return len(m.Selected) > 0 && r < len(m.RowIndex) && m.Selected[m.RowIndex[r]]
+}
+
+// selectionEnabled 是否允许多选，单选的选择器模式下禁用
+func (m TableModel) selectionEnabled() bool {
+	return m.HasFeature(FeatureSelect) && (!m.PickMode || m.MultiSelect)
+}
+
+// ExtendSelection 选中光标所在行后向下（delta>0）或向上移动光标，用于连续范围选择
+func (m *TableModel) ExtendSelection(delta int) {
+	idx, ok := m.cursorRowIndex()
+	if !ok {
+		return
+	}
+	if m.Selected == nil {
+		m.Selected = make(map[int]bool)
+	}
+	m.Selected[idx] = true
+	m.Cursor.Move(delta)
+	// 移动后的行同样纳入范围
+	if idx, ok := m.cursorRowIndex(); ok {
+		m.Selected[idx] = true
+	}
+}
+
+// SelectAll 选中当前筛选结果中的全部行
+func (m *TableModel) SelectAll() {
+	if m.RowCount == 0 {
+		return
+	}
+	if m.Selected == nil {
+		m.Selected = make(map[int]bool)
+	}
+	for _, idx := range m.RowIndex {
+		m.Selected[idx] = true
+	}
+}
+
+// InvertSelection 在当前筛选结果范围内反选
+func (m *TableModel) InvertSelection() {
+	if m.RowCount == 0 {
+		return
+	}
+	if m.Selected == nil {
+		m.Selected = make(map[int]bool)
+	}
+	for _, idx := range m.RowIndex {
+		if m.Selected[idx] {
+			delete(m.Selected, idx)
+		} else {
+			m.Selected[idx] = true
+		}
+	}
+}
+
+// ClearSelection 清除全部选中行
+func (m *TableModel) ClearSelection() {
+	m.Selected = nil
+}
+
+// selectedTableRows 返回选中的行数据，没有多选时返回光标所在行
+func (m TableModel) selectedTableRows() []table.Row {
+	selected := m.SelectedRows()
+	rows := make([]table.Row, len(selected))
+	for i, row := range selected {
+		rows[i] = row.Values
+	}
+	return rows
+}
+
+// copySelection 将选中行以制表符分隔（含表头）复制到剪贴板
+func (m *TableModel) copySelection() {
+	rows := m.selectedTableRows()
+	var b strings.Builder
+	headers := make([]string, len(m.TableColumns))
+	for i, col := range m.TableColumns {
+		headers[i] = col.Title
+	}
+	b.WriteString(strings.Join(headers, "\t"))
+	for _, row := range rows {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(row, "\t"))
+	}
+
+	if err := clipboard.WriteAll(b.String()); err != nil {
+		m.StatusMsg = fmt.Sprintf("复制失败: %v", err)
+	} else {
+		m.StatusMsg = fmt.Sprintf("已复制 %d 行", len(rows))
+	}
+}
+
+// copyCell 将光标所在单元格复制到剪贴板
+func (m *TableModel) copyCell() {
+	if len(m.TableColumns) == 0 {
+		return
+	}
+	if err := clipboard.WriteAll(m.SelectedCell()); err != nil {
+		m.StatusMsg = fmt.Sprintf("复制失败: %v", err)
+	} else {
+		m.StatusMsg = fmt.Sprintf("已复制 [%s] 列的单元格内容", m.TableColumns[m.ColCursor].Title)
+	}
+}
+
+// exportRows 导出指定行并更新状态消息
+func (m *TableModel) exportRows(rows []SelectedRow) {
+	if m.diff != nil {
+		csvPath, jsonPath, err := m.ExportDiff(rows)
+		if err != nil {
+			m.StatusMsg = fmt.Sprintf("导出失败: %v", err)
+		} else {
+			m.StatusMsg = fmt.Sprintf("导出成功: %s, %s (%d 行)", csvPath, jsonPath, len(rows))
+		}
+		return
+	}
+	values := make([]table.Row, len(rows))
+	for i, row := range rows {
+		values[i] = row.Values
+	}
+	if err := m.ExportRowsToCSV(values); err != nil {
+		m.StatusMsg = fmt.Sprintf("导出失败: %v", err)
+	} else {
+		m.StatusMsg = fmt.Sprintf("导出成功: %s (%d 行)", m.ExportPath(), len(rows))
+	}
+}
+
+// updateScopePrompt 处理导出/复制范围的选择
+func (m *TableModel) updateScopePrompt(msg tea.KeyMsg) {
+	scope := m.ScopePrompt
+	m.ScopePrompt = scopeNone
+	switch msg.String() {
+	case "s":
+		switch scope {
+		case scopeExport:
+			m.exportRows(m.SelectedRows())
+		case scopeCopy:
+			m.copySelection()
+		case scopeGroupExport:
+			m.exportRows(m.groupedRows())
+		}
+	case "a":
+		if scope == scopeExport {
+			m.exportRows(m.displayedRows())
+		}
+	case "c":
+		if scope == scopeCopy {
+			m.copyCell()
+		}
+	case "g":
+		if scope == scopeGroupExport {
+			if err := m.ExportGroups(); err != nil {
+				m.StatusMsg = fmt.Sprintf("导出失败: %v", err)
+			} else {
+				m.StatusMsg = fmt.Sprintf("导出成功: %s (%d 组)", m.GroupExportPath(), len(m.grouping.groups))
+			}
+		}
+	case "d":
+		if scope == scopeGroupExport {
+			m.exportRows(m.groupedRows())
+		}
+	default:
+		m.StatusMsg = ""
+	}
+}
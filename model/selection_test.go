@@ -0,0 +1,106 @@
+package model
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectionData 选择测试用的数据，第二列用于筛选
+var selectionData = TableData{
+	Headers: []string{"ID", "组"},
+	Rows:    [][]string{{"1", "x"}, {"2", "y"}, {"3", "x"}, {"4", "y"}, {"5", "x"}},
+}
+
+// selectedIndexes 返回按原始顺序排列的选中行索引
+func selectedIndexes(m TableModel) []int {
+	var got []int
+	for idx := range m.Selected {
+		got = append(got, idx)
+	}
+	sort.Ints(got)
+	return got
+}
+
+// TestSelection 多选按键：切换、范围选择、全选与反选只作用于筛选结果、清除
+func TestSelection(t *testing.T) {
+	filterX := []string{"right", "f", "x", "enter", "left"}
+	cases := []struct {
+		name string
+		pick bool // 单选的选择器模式
+		keys []string
+		want []int
+	}{
+		{"切换", false, []string{"space", "space", "up", "space"}, []int{0}},
+		{"向下扩展", false, []string{"J", "J"}, []int{0, 1, 2}},
+		{"向上扩展", false, []string{"down", "down", "down", "K", "K"}, []int{1, 2, 3}},
+		{"全选", false, []string{"a"}, []int{0, 1, 2, 3, 4}},
+		{"全选只作用于筛选结果", false, append(filterX, "a"), []int{0, 2, 4}},
+		{"反选", false, []string{"space", "a", "i"}, nil},
+		{"筛选后反选", false, append(filterX, "space", "i"), []int{2, 4}},
+		{"清除", false, []string{"a", "u"}, nil},
+		{"单选的选择器禁用多选", true, []string{"space", "J", "a"}, nil},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, selectionData)
+		m.PickMode = c.pick
+		m = press(t, m, c.keys...)
+		if got := selectedIndexes(m); !equalInts(got, c.want) {
+			t.Errorf("%s: 选中 %v，期望 %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestSelectedRows 有多选时按原始顺序返回选中行，否则返回光标所在行
+func TestSelectedRows(t *testing.T) {
+	cases := []struct {
+		name string
+		keys []string
+		want []int
+	}{
+		{"光标所在行", []string{"down"}, []int{1}},
+		{"排序后仍按原始顺序", []string{"s", "s", "up", "up", "space", "space"}, []int{1, 2}},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, selectionData), c.keys...)
+		var got []int
+		for _, row := range m.SelectedRows() {
+			got = append(got, row.Index)
+		}
+		if !equalInts(got, c.want) {
+			t.Errorf("%s: 选中 %v，期望 %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestBulkAction 批量操作作用于选中行，只读模式下不执行
+func TestBulkAction(t *testing.T) {
+	cases := []struct {
+		name     string
+		readOnly bool
+		keys     []string
+		want     int // 操作收到的行数，-1 表示未执行
+	}{
+		{"选中行", false, []string{"space", "space"}, 2},
+		{"没有多选时为光标所在行", false, nil, 1},
+		{"只读", true, []string{"space"}, -1},
+	}
+	for _, c := range cases {
+		got := -1
+		var opts []Option
+		if c.readOnly {
+			opts = append(opts, WithReadOnly())
+		}
+		m := newTestModel(t, selectionData, opts...)
+		m.RegisterBulkAction(BulkAction{Name: "删除", Key: "D", Run: func(rows []table.Row) tea.Cmd {
+			got = len(rows)
+			return nil
+		}})
+		m = press(t, m, append(c.keys, "D")...)
+		if got != c.want {
+			t.Errorf("%s: 操作收到 %d 行，期望 %d", c.name, got, c.want)
+		}
+	}
+}
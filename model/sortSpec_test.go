@@ -0,0 +1,75 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sortData 排序测试使用的数据，数量列按数值比较时与按文本比较的顺序不同
+var sortData = TableData{
+	Headers: []string{"ID", "城市", "数量"},
+	Rows: [][]string{
+		{"1", "北京", "9"}, {"2", "上海", "10"}, {"3", "北京", "100"},
+		{"4", "上海", "9"}, {"5", "北京", ""},
+	},
+}
+
+// TestSortStack 单列排序按 升序 → 降序 → 取消 切换，追加的排序键作为次级排序，相同的值保持原来的顺序
+func TestSortStack(t *testing.T) {
+	cases := []struct {
+		name string
+		keys []string
+		sort []SortKey
+		ids  string
+		head []string // 各列标题上的排序标记
+	}{
+		{"升序", []string{"right", "right", "s"}, []SortKey{{2, false}}, "5 1 4 2 3", []string{"", "", "▲"}},
+		{"降序", []string{"right", "right", "s", "s"}, []SortKey{{2, true}}, "3 2 1 4 5", []string{"", "", "▼"}},
+		{"取消", []string{"right", "right", "s", "s", "s"}, nil, "1 2 3 4 5", []string{"", "", ""}},
+		{"另一列替换排序栈", []string{"right", "s", "right", "s"}, []SortKey{{2, false}}, "5 1 4 2 3", []string{"", "", "▲"}},
+		{
+			"次级排序", []string{"right", "s", "right", "S", "S"}, []SortKey{{1, false}, {2, true}}, "2 4 3 1 5",
+			[]string{"", "▲1", "▼2"},
+		},
+		{
+			"单列排序替换多键排序", []string{"right", "s", "right", "S", "s"}, []SortKey{{2, false}}, "5 1 4 2 3",
+			[]string{"", "", "▲"},
+		},
+		{"移除次级排序", []string{"right", "s", "right", "S", "S", "S"}, []SortKey{{1, false}}, "2 4 1 3 5", []string{"", "▲", ""}},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, sortData), c.keys...)
+		if len(m.SortKeys) != len(c.sort) || (len(c.sort) > 0 && !reflect.DeepEqual(m.SortKeys, c.sort)) {
+			t.Errorf("%s: 排序栈 %v，期望 %v", c.name, m.SortKeys, c.sort)
+		}
+		var ids []string
+		for _, row := range m.OriginalRows {
+			ids = append(ids, row[0])
+		}
+		if got := strings.Join(ids, " "); got != c.ids {
+			t.Errorf("%s: 顺序 %s，期望 %s", c.name, got, c.ids)
+		}
+		for col, want := range c.head {
+			if got := m.sortIndicator(col); got != want {
+				t.Errorf("%s: 第 %d 列的排序标记为 %q，期望 %q", c.name, col, got, want)
+			}
+		}
+	}
+}
+
+// TestSetSort 忽略越界和重复的排序键
+func TestSetSort(t *testing.T) {
+	m := newTestModel(t, sortData)
+	m.SetSort(SortKey{Column: 2, Desc: true}, SortKey{Column: 9}, SortKey{Column: 2}, SortKey{Column: 0, Desc: true})
+	want := []SortKey{{2, true}, {0, true}}
+	if !reflect.DeepEqual(m.SortKeys, want) {
+		t.Errorf("排序栈 %v，期望 %v", m.SortKeys, want)
+	}
+	if got := m.sortDescription(); got != "数量(降序) > ID(降序)" {
+		t.Errorf("排序描述为 %q", got)
+	}
+	if m.OriginalRows[0][0] != "3" || m.OriginalRows[2][0] != "4" {
+		t.Errorf("顺序为 %v", m.OriginalRows)
+	}
+}
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 流式接收时每批最多合并的行数和等待时间，避免每来一行就重新排序/筛选
+const (
+	streamBatchSize   = 1000
+	streamBatchWindow = 100 * time.Millisecond
+)
+
+// rowStream 正在接收的数据流
+type rowStream struct {
+	rows     <-chan []string
+	errs     <-chan error
+	start    time.Time
+	infer    bool // 列类型是否需要根据第一批数据推断
+	explicit int  // 调用方定义了类型的列数，这些列不参与推断
+}
+
+// streamRowsMsg 从数据流收到一批数据行
+type streamRowsMsg struct {
+	rows [][]string
+	done bool  // 数据流已结束
+	err  error // 数据流出错
+}
+
+// streamTickMsg 刷新接收耗时
+type streamTickMsg struct{}
+
+// ShowStream 边接收边显示数据行
+// data 提供标题、表头和可选的列定义（其中的 Rows 作为已有数据先显示），未提供列定义时按第一批数据推断类型。
+// rows 关闭表示接收完成；errs 可以为 nil，出错时应在关闭 rows 之前发送错误，收到错误后停止接收。
+func ShowStream(data TableData, rows <-chan []string, errs <-chan error, opts ...Option) error {
+	cfg := newConfig(opts)
+	m, err := newStreamModel(data, rows, errs, cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = runProgram(m, cfg.programOptions()...)
+	return err
+}
+
+// NewTableModelFromStream 根据数据流构建表格模型，数据在 Init 返回的命令中开始接收
+func NewTableModelFromStream(data TableData, rows <-chan []string, errs <-chan error, opts ...Option) (TableModel, error) {
+	return newStreamModel(data, rows, errs, newConfig(opts))
+}
+
+// StreamFromSeq 将迭代器转换为 ShowStream 所需的数据行和错误通道，迭代器返回错误时停止。
+// 表格不再接收（例如程序已退出）时取消 ctx 以结束转换的 goroutine，取消时发送 ctx 的错误
+func StreamFromSeq(ctx context.Context, seq iter.Seq2[[]string, error]) (<-chan []string, <-chan error) {
+	rows := make(chan []string)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(rows)
+		defer close(errs)
+		for row, err := range seq {
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case rows <- row:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return rows, errs
+}
+
+// newStreamModel 构建接收数据流的表格模型
+func newStreamModel(data TableData, rows <-chan []string, errs <-chan error, cfg config) (TableModel, error) {
+	m := newSourceModel(data, cfg)
+	if m.Title == "" {
+		m.Title = data.Title
+	}
+	m.Source = nil
+	m.stream = &rowStream{
+		rows:     rows,
+		errs:     errs,
+		start:    time.Now(),
+		infer:    len(data.Columns) < len(m.Columns) && len(data.Rows) == 0,
+		explicit: len(data.Columns),
+	}
+	m.appendRows(data.Rows)
+	return m.configure(cfg)
+}
+
+// waitStream 等待下一批数据行
+func (m TableModel) waitStream() tea.Cmd {
+	if m.stream == nil {
+		return nil
+	}
+	rows, errs := m.stream.rows, m.stream.errs
+	return func() tea.Msg {
+		var batch [][]string
+
+		// 阻塞等待第一行
+		select {
+		case row, ok := <-rows:
+			if !ok {
+				return streamRowsMsg{done: true, err: pendingError(errs)}
+			}
+			batch = append(batch, row)
+		case err, ok := <-errs:
+			if ok && err != nil {
+				return streamRowsMsg{done: true, err: err}
+			}
+			// 错误通道关闭，之后只等待数据行
+			errs = nil
+			row, ok := <-rows
+			if !ok {
+				return streamRowsMsg{done: true}
+			}
+			batch = append(batch, row)
+		}
+
+		// 在时间窗口内合并后续到达的行
+		timer := time.NewTimer(streamBatchWindow)
+		defer timer.Stop()
+		for len(batch) < streamBatchSize {
+			select {
+			case row, ok := <-rows:
+				if !ok {
+					return streamRowsMsg{rows: batch, done: true, err: pendingError(errs)}
+				}
+				batch = append(batch, row)
+			case err, ok := <-errs:
+				if ok && err != nil {
+					return streamRowsMsg{rows: batch, done: true, err: err}
+				}
+				errs = nil
+			case <-timer.C:
+				return streamRowsMsg{rows: batch}
+			}
+		}
+		return streamRowsMsg{rows: batch}
+	}
+}
+
+// pendingError 数据行通道关闭后检查是否有未读取的错误
+func pendingError(errs <-chan error) error {
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+// streamTick 每秒刷新一次接收耗时
+func streamTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return streamTickMsg{}
+	})
+}
+
+// handleStreamRows 追加收到的数据行，数据流未结束时继续等待
+func (m *TableModel) handleStreamRows(msg streamRowsMsg) tea.Cmd {
+	if m.stream == nil {
+		return nil
+	}
+	if m.stream.infer && len(msg.rows) > 0 {
+		m.inferStreamColumns(msg.rows)
+	}
+	m.QueryDuration = time.Since(m.stream.start)
+	m.appendRows(msg.rows)
+
+	if !msg.done {
+		return m.waitStream()
+	}
+	m.stream = nil
+	m.StreamErr = msg.err
+	if msg.err != nil {
+		m.StatusMsg = fmt.Sprintf("接收失败: %v", msg.err)
+	} else {
+		m.streamDone = true
+	}
+	m.finishSource()
+	return nil
+}
+
+// inferStreamColumns 按第一批数据推断未定义的列类型
+func (m *TableModel) inferStreamColumns(rows [][]string) {
+	m.stream.infer = false
+	inferred := InferColumnSpecs(m.columnNames(), rows)
+	for i := m.stream.explicit; i < len(m.Columns); i++ {
+		m.Columns[i].Type = inferred[i].Type
+	}
+}
+
+// columnNames 返回全部列标题
+func (m TableModel) columnNames() []string {
+	names := make([]string, len(m.TableColumns))
+	for i, col := range m.TableColumns {
+		names[i] = col.Title
+	}
+	return names
+}
+
+// streamStatus 返回数据流的接收状态
+func (m TableModel) streamStatus() string {
+	switch {
+	case m.stream != nil:
+		return fmt.Sprintf("接收中… 已收到 %d 行", m.RowCount)
+	case m.StreamErr != nil:
+		return "接收失败"
+	case m.streamDone:
+		return fmt.Sprintf("接收完成: 共 %d 行，用时 %s", m.RowCount, m.QueryDuration.Round(time.Millisecond))
+	}
+	return ""
+}
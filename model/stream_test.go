@@ -0,0 +1,155 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// bufferedStream 返回已写入全部数据行并关闭的通道，err 不为 nil 时写入错误通道
+func bufferedStream(rows [][]string, err error) (<-chan []string, <-chan error) {
+	rowCh := make(chan []string, len(rows))
+	for _, row := range rows {
+		rowCh <- row
+	}
+	close(rowCh)
+	errCh := make(chan error, 1)
+	if err != nil {
+		errCh <- err
+	}
+	close(errCh)
+	return rowCh, errCh
+}
+
+// TestWaitStream 按批接收数据行，每批不超过 streamBatchSize，通道关闭或出错时结束
+func TestWaitStream(t *testing.T) {
+	errBroken := errors.New("连接断开")
+	cases := []struct {
+		name      string
+		rows      int
+		err       error
+		nilErrs   bool
+		wantSizes []int
+		wantErr   error
+	}{
+		{"一批", 3, nil, false, []int{3}, nil},
+		{"多批", streamBatchSize + 500, nil, false, []int{streamBatchSize, 500}, nil},
+		{"没有数据", 0, nil, false, []int{0}, nil},
+		{"没有错误通道", 2, nil, true, []int{2}, nil},
+		{"出错", 0, errBroken, false, []int{0}, errBroken},
+	}
+	for _, c := range cases {
+		rows, errs := bufferedStream(numberedRows(c.rows), c.err)
+		if c.nilErrs {
+			errs = nil
+		}
+		m := TableModel{stream: &rowStream{rows: rows, errs: errs}}
+
+		var sizes []int
+		var last streamRowsMsg
+		for cmd := m.waitStream(); ; {
+			last = cmd().(streamRowsMsg)
+			sizes = append(sizes, len(last.rows))
+			if last.done {
+				break
+			}
+		}
+		if !slices.Equal(sizes, c.wantSizes) || !errors.Is(last.err, c.wantErr) {
+			t.Errorf("%s: 每批 %v、错误 %v，期望 %v、%v", c.name, sizes, last.err, c.wantSizes, c.wantErr)
+		}
+	}
+}
+
+// TestStreamModel 接收数据流：未定义的列按第一批数据推断类型，结束或出错时更新状态
+func TestStreamModel(t *testing.T) {
+	cases := []struct {
+		name       string
+		columns    []ColumnSpec
+		err        error
+		wantTypes  []ColumnType
+		wantStatus string
+	}{
+		{"推断类型", nil, nil, []ColumnType{ColumnInt, ColumnFloat}, "接收完成: 共 3 行"},
+		{"保留定义的类型", []ColumnSpec{{Name: "ID", Type: ColumnString}}, nil, []ColumnType{ColumnString, ColumnFloat}, "接收完成: 共 3 行"},
+		{"出错", nil, errors.New("连接断开"), []ColumnType{ColumnString, ColumnString}, "接收失败"},
+	}
+	for _, c := range cases {
+		var sent [][]string
+		if c.err == nil {
+			sent = [][]string{{"1", "0.5"}, {"2", "1"}, {"3", "2.25"}}
+		}
+		rows, errs := bufferedStream(sent, c.err)
+		data := TableData{Headers: []string{"ID", "比例"}, Columns: c.columns}
+		m, err := NewTableModelFromStream(data, rows, errs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for cmd := m.waitStream(); cmd != nil; {
+			cmd = m.handleStreamRows(cmd().(streamRowsMsg))
+		}
+
+		var types []ColumnType
+		for _, spec := range m.Columns {
+			types = append(types, spec.Type)
+		}
+		if !slices.Equal(types, c.wantTypes) {
+			t.Errorf("%s: 列类型 %v，期望 %v", c.name, types, c.wantTypes)
+		}
+		if status := m.streamStatus(); !strings.HasPrefix(status, c.wantStatus) {
+			t.Errorf("%s: 状态 %q，期望以 %q 开头", c.name, status, c.wantStatus)
+		}
+		if c.err != nil && (m.StreamErr == nil || !strings.Contains(m.StatusMsg, c.err.Error())) {
+			t.Errorf("%s: 没有记录错误: %v %q", c.name, m.StreamErr, m.StatusMsg)
+		}
+	}
+}
+
+// TestStreamFromSeq 迭代器返回错误或 ctx 取消时停止转换并发送错误
+func TestStreamFromSeq(t *testing.T) {
+	errBroken := errors.New("连接断开")
+	seq := func(n int, err error) iter.Seq2[[]string, error] {
+		return func(yield func([]string, error) bool) {
+			for _, row := range numberedRows(n) {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(nil, err)
+			}
+		}
+	}
+	cases := []struct {
+		name     string
+		seq      iter.Seq2[[]string, error]
+		take     int // 读取这么多行后取消，-1 表示读完
+		wantRows int
+		wantErr  error
+	}{
+		{"读完", seq(3, nil), -1, 3, nil},
+		{"迭代器出错", seq(2, errBroken), -1, 2, errBroken},
+		{"取消", seq(10, nil), 1, 1, context.Canceled},
+	}
+	for _, c := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		rows, errs := StreamFromSeq(ctx, c.seq)
+		got := 0
+		for range rows {
+			if got++; got == c.take {
+				cancel()
+				break
+			}
+		}
+		// 取消后 goroutine 发送错误并关闭通道
+		for range rows {
+		}
+		err := <-errs
+		cancel()
+		if got != c.wantRows || !errors.Is(err, c.wantErr) {
+			t.Errorf("%s: 收到 %d 行、错误 %v，期望 %d 行、%v", c.name, got, err, c.wantRows, c.wantErr)
+		}
+	}
+}
@@ -1,21 +1,22 @@
 package model
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"sort"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"golang.org/x/term"
 )
 
 // 定义样式
@@ -37,6 +38,10 @@ var (
 			Foreground(lipgloss.Color("241")).
 			MarginLeft(2)
 
+	filterErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196")).
+				MarginLeft(2)
+
 	statusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("35")).
 			Bold(true).
@@ -45,20 +50,50 @@ var (
 
 // KeyMap 定义键盘映射
 type KeyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Left     key.Binding
-	Right    key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	Sort     key.Binding
-	Filter   key.Binding
-	Export   key.Binding
-	Reset    key.Binding
-	PageUp   key.Binding
-	PageDown key.Binding
-	Home     key.Binding
-	End      key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Help       key.Binding
+	Quit       key.Binding
+	Cancel     key.Binding
+	Retry      key.Binding
+	Refresh    key.Binding
+	DiffOnly   key.Binding
+	DiffDetail key.Binding
+	Sort       key.Binding
+	SortAdd    key.Binding
+	Filter     key.Binding
+	Export     key.Binding
+	Copy       key.Binding
+	Select     key.Binding
+	SelectDown key.Binding
+	SelectUp   key.Binding
+	SelectAll  key.Binding
+	Invert     key.Binding
+	Deselect   key.Binding
+	Confirm    key.Binding
+	Detail     key.Binding
+	Inspect    key.Binding
+	FoldNode   key.Binding
+	FoldTree   key.Binding
+	CopyRaw    key.Binding
+	Stats      key.Binding
+	Frequency  key.Binding
+	Group      key.Binding
+	Fold       key.Binding
+	FoldAll    key.Binding
+	Pane       key.Binding
+	Search     key.Binding
+	NextHit    key.Binding
+	PrevHit    key.Binding
+	Reset      key.Binding
+	Unfilter   key.Binding
+	PickChip   key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Home       key.Binding
+	End        key.Binding
 }
 
 // ShortHelp 返回简短帮助信息
@@ -71,8 +106,12 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.PageUp, k.PageDown, k.Home, k.End},
-		{k.Sort, k.Filter, k.Reset, k.Export},
-		{k.Help, k.Quit},
+		{k.Sort, k.SortAdd, k.Filter, k.Unfilter, k.PickChip, k.Reset},
+		{k.Search, k.NextHit, k.PrevHit, k.Export, k.Copy, k.Inspect, k.Stats, k.Frequency, k.Pane},
+		{k.Group, k.Fold, k.FoldAll},
+		{k.DiffOnly, k.DiffDetail},
+		{k.Select, k.SelectDown, k.SelectUp, k.SelectAll, k.Invert, k.Deselect},
+		{k.Detail, k.Confirm, k.Cancel, k.Refresh, k.Help, k.Quit},
 	}
 }
 
@@ -102,10 +141,34 @@ var Keys = KeyMap{
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "退出"),
 	),
+	Cancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "取消查询或后台排序/筛选"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "加载失败时重试"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "刷新数据"),
+	),
+	DiffOnly: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "只显示有变更的行"),
+	),
+	DiffDetail: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "查看修改前的值"),
+	),
 	Sort: key.NewBinding(
 		key.WithKeys("s"),
 		key.WithHelp("s", "排序当前列"),
 	),
+	SortAdd: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "追加次级排序"),
+	),
 	Filter: key.NewBinding(
 		key.WithKeys("f"),
 		key.WithHelp("f", "筛选当前列"),
@@ -114,9 +177,105 @@ var Keys = KeyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "导出CSV"),
 	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "全表搜索"),
+	),
+	NextHit: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "下一个匹配"),
+	),
+	PrevHit: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "上一个匹配"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "选择/取消选择"),
+	),
+	SelectDown: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "向下扩展选择"),
+	),
+	SelectUp: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "向上扩展选择"),
+	),
+	SelectAll: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "全选筛选结果"),
+	),
+	Invert: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "反选"),
+	),
+	Deselect: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "清除选择"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "确认选择"),
+	),
+	Detail: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "查看记录详情"),
+	),
+	Inspect: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "查看单元格完整内容"),
+	),
+	FoldNode: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "折叠/展开"),
+	),
+	FoldTree: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "全部折叠/展开"),
+	),
+	CopyRaw: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "复制原始内容"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "当前列统计"),
+	),
+	Frequency: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "当前列值分布"),
+	),
+	Group: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "按当前列分组/取消"),
+	),
+	Fold: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "展开/折叠分组"),
+	),
+	FoldAll: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "展开/折叠全部分组"),
+	),
+	Pane: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "显示/隐藏预览栏"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "复制单元格"),
+	),
 	Reset: key.NewBinding(
 		key.WithKeys("r"),
-		key.WithHelp("r", "重置筛选"),
+		key.WithHelp("r", "清除全部筛选"),
+	),
+	Unfilter: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "移除最后一个筛选条件"),
+	),
+	PickChip: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "选择要移除的筛选条件"),
 	),
 	PageUp: key.NewBinding(
 		key.WithKeys("shift+up"),
@@ -138,28 +297,112 @@ var Keys = KeyMap{
 
 // TableModel 表格模型
 type TableModel struct {
-	Table         table.Model
-	Title         string
-	RowCount      int
-	QueryDuration time.Duration
-	Help          help.Model
-	Keys          KeyMap
-	Paginator     paginator.Model
-	ShowHelp      bool
-	SortColumn    int             // 当前排序的列
-	SortAsc       bool            // 是否升序排序
-	OriginalRows  []table.Row     // 保存原始数据行
-	FilteredRows  []table.Row     // 保存过滤后的数据行
-	AllRows       []table.Row     // 保存所有原始数据行（用于恢复）
-	FilterText    string          // 过滤文本
-	Filtering     bool            // 是否在过滤状态
-	StatusMsg     string          // 状态消息
-	TableColumns  []table.Column  // 表格列定义
-	ScrollOffset  int             // 横向滚动偏移量
-	MaxColumns    int             // 当前能显示的最大列数
-	Width         int             // 当前表格宽度
-	Height        int             // 当前表格高度
-	TextInput     textinput.Model // 文本输入模型，用于筛选
+	Cursor           RowCursor // 行光标，OriginalRows 中的位置
+	Title            string
+	RowCount         int
+	QueryDuration    time.Duration
+	Help             help.Model
+	Keys             KeyMap
+	Paginator        paginator.Model
+	ShowHelp         bool
+	SortKeys         []SortKey       // 排序栈，按优先级从高到低
+	FilterColumn     int             // 当前筛选的列
+	OriginalRows     []table.Row     // 保存原始数据行
+	FilteredRows     []table.Row     // 保存过滤后的数据行
+	filteredIndex    []int           // FilteredRows 中每一行在 AllRows 中的索引
+	RowIndex         []int           // OriginalRows 中每一行在 AllRows 中的索引
+	AllRows          []table.Row     // 保存所有原始数据行（用于恢复）
+	Filters          []FilterExpr    // 已生效的筛选条件，逐个叠加
+	FilterErr        error           // 筛选输入的解析错误
+	Filtering        bool            // 是否在过滤状态
+	PickingFilter    bool            // 是否在选择要移除的筛选条件
+	FilterChipCursor int             // 选择模式下当前选中的筛选条件
+	StatusMsg        string          // 状态消息
+	infoStatus       string          // 内容已由信息行体现的状态消息，不再单独显示
+	TableColumns     []table.Column  // 表格列定义
+	Columns          []ColumnSpec    // 列类型定义，与 TableColumns 一一对应
+	ScrollOffset     int             // 横向滚动偏移量
+	ColCursor        int             // 光标所在列（绝对索引）
+	RowOffset        int             // 可见区域第一行的索引
+	MaxColumns       int             // 当前能显示的最大列数
+	Width            int             // 当前表格宽度
+	Height           int             // 当前表格高度
+	viewWidth        int             // 组件总宽度
+	viewHeight       int             // 组件总高度
+	TextInput        textinput.Model // 文本输入模型，用于筛选
+	Searching        bool            // 是否在输入搜索文本
+	SearchText       string          // 当前搜索文本
+	SearchInput      textinput.Model // 搜索输入框
+	SearchMatches    []SearchMatch   // 搜索命中的单元格
+	SearchIndex      int             // 当前命中在 SearchMatches 中的位置
+	searchJob        *searchJob      // 正在后台执行的搜索
+	searchGen        int             // 搜索任务序号，用于丢弃被取代的结果
+	searchPending    bool            // 是否有待执行的搜索
+	searchUrgent     bool            // 待执行的搜索由输入触发，需要取代正在执行的搜索
+	searchJump       bool            // 得到命中后是否跳转到光标之后的第一个命中
+	rowsVersion      int             // 显示行的版本，每次替换显示行时递增
+	PickMode         bool            // 是否为选择器模式
+	MultiSelect      bool            // 选择器模式下是否允许多选
+	Selected         map[int]bool    // 选中的行（AllRows 中的索引）
+	Picked           []SelectedRow   // 确认后的选择结果
+	BulkActions      []BulkAction    // 已注册的批量操作
+	ScopePrompt      int             // 正在询问作用范围的操作（导出/复制）
+	Theme            Theme           // 界面配色
+	ExportDir        string          // CSV 导出目录
+	ReadOnly         bool            // 只读模式，禁用导出和批量操作
+	Features         Feature         // 已启用的功能
+	Source           DataSource      // 数据源
+	PageSize         int             // 每次从数据源读取的行数
+	Loading          bool            // 是否正在读取下一页
+	LoadErr          error           // 最近一次读取的错误
+	sourceDone       bool            // 数据源是否已全部读取
+	sourceGen        int             // 数据源重新加载的次数，用于丢弃过期的读取结果
+	minColWidth      int             // 列宽下限，追加数据时重新计算列宽用
+	maxColWidth      int             // 列宽上限
+	emptyText        string          // 没有数据时的占位文本
+	Spinner          spinner.Model   // 后台排序/筛选时显示的动画
+	viewJob          *viewJob        // 正在后台执行的排序/筛选
+	viewGen          int             // 后台任务序号，用于丢弃被取代的结果
+	viewPending      bool            // 是否有待执行的排序/筛选
+	viewRefilter     bool            // 待执行的任务是否需要重新筛选
+	appliedFilters   []FilterExpr    // 当前显示结果对应的筛选条件
+	appliedSort      []SortKey       // 当前显示结果对应的排序栈
+	appliedDiffOnly  bool            // 当前显示结果是否只保留有变更的行
+	appliedRows      int             // 当前显示结果计算时的数据行数
+	followRow        int             // 重新计算后光标应停留的行（AllRows 中的索引），-1 表示不跟随
+	viewUrgent       bool            // 待执行的任务由用户操作触发，需要取代正在执行的任务
+	appending        bool            // 是否正在追加数据行
+	stream           *rowStream      // 正在接收的数据流
+	StreamErr        error           // 数据流的错误
+	streamDone       bool            // 数据流是否已正常结束
+	Querying         bool            // 是否正在执行查询
+	loader           *queryLoader    // 查询函数，由 ShowLoader 设置
+	dataLoaded       bool            // 查询是否已成功返回数据
+	loadGen          int             // 查询序号，用于丢弃被取消或取代的结果
+	loadStart        time.Time       // 本次查询的开始时间
+	loadCancel       context.CancelFunc
+	sourceCancel     context.CancelFunc
+	followScreen     int              // 重新定位光标时保持的屏幕行，-1 表示不保持
+	refreshInterval  time.Duration    // 自动刷新间隔，0 表示不自动刷新
+	RefreshedAt      time.Time        // 最近一次获得数据的时间
+	changedCells     map[cellRef]bool // 最近一次刷新中变化的单元格
+	changeGen        int              // 刷新序号，用于结束对应的高亮
+	keyNames         []string         // 数据源声明的主键列名
+	keyColumns       []int            // 主键列的索引，为空时按 AllRows 中的索引识别记录
+	pendingMarks     *rowMarks        // 数据源重新读取后待恢复的光标和选择
+	diff             *tableDiff       // 差异模式下两次结果的差异
+	ShowDiffDetail   bool             // 是否显示光标所在记录修改前后的值
+	ShowDiffOnly     bool             // 差异模式下是否只显示有变更的行
+	detail           *recordDetail    // 记录详情，nil 表示未打开
+	inspector        *cellInspector   // 单元格查看器，nil 表示未打开
+	stats            *statsPanel      // 列统计面板，nil 表示未打开
+	freq             *freqPanel       // 值分布面板，nil 表示未打开
+	statsGen         int              // 列统计和值分布任务的序号
+	grouping         *groupView       // 分组模式的状态，nil 表示未分组
+	aggregates       []Aggregate      // 分组标题行中显示的汇总项
+	ShowPane         bool             // 是否在右侧显示预览栏
+	PaneWidth        int              // 预览栏宽度，小于等于 0 时按总宽度计算
+	PaneRenderer     PaneRenderer     // 自定义预览栏内容，为 nil 时显示记录详情
 }
 
 // NewTableModel 初始化表格模型
@@ -175,34 +418,51 @@ func NewTableModel() TableModel {
 
 	// 初始化文本输入模型
 	ti := textinput.New()
-	ti.Placeholder = "输入筛选文本..."
+	ti.Placeholder = "输入筛选文本或表达式，如 年龄 > 30 and 城市 in (北京, 上海)"
 	ti.Focus()
-	ti.Width = 20
-	ti.CharLimit = 50
+	ti.Width = 60
+	ti.CharLimit = 200
+
+	// 初始化搜索输入模型
+	si := textinput.New()
+	si.Placeholder = "搜索全部单元格..."
+	si.Prompt = "/"
+	si.Width = 40
+	si.CharLimit = 100
 
 	return TableModel{
 		Help:         h,
 		Keys:         Keys,
 		Paginator:    p,
 		ShowHelp:     false,
-		SortColumn:   -1, // 默认不排序
-		SortAsc:      true,
+		FilterColumn: -1,
 		Filtering:    false,
 		ScrollOffset: 0, // 初始无偏移
 		TextInput:    ti,
+		SearchInput:  si,
+		Theme:        DefaultTheme(),
+		ExportDir:    DefaultExportDir,
+		Features:     AllFeatures,
+		Spinner:      spinner.New(spinner.WithSpinner(spinner.MiniDot)),
+		followRow:    -1,
+		followScreen: -1,
 	}
 }
 
 // ExportToCSV 导出表格数据到CSV文件
 func (m *TableModel) ExportToCSV() error {
+	return m.ExportRowsToCSV(m.OriginalRows)
+}
+
+// ExportRowsToCSV 导出指定数据行到CSV文件
+func (m *TableModel) ExportRowsToCSV(rows []table.Row) error {
 	// 创建输出目录
-	if err := os.MkdirAll("output", 0755); err != nil {
+	if err := os.MkdirAll(m.exportDir(), 0755); err != nil {
 		return fmt.Errorf("创建输出目录失败: %v", err)
 	}
 
 	// 打开文件用于写入
-	outputFile := "output/查询结果.csv"
-	f, err := os.Create(outputFile)
+	f, err := os.Create(m.ExportPath())
 	if err != nil {
 		return fmt.Errorf("创建输出文件失败: %v", err)
 	}
@@ -213,25 +473,45 @@ func (m *TableModel) ExportToCSV() error {
 	for _, col := range m.TableColumns {
 		headers = append(headers, col.Title)
 	}
-	fmt.Fprintln(f, strings.Join(headers, ","))
+	fmt.Fprint(f, csvLine(headers))
 
-	// 写入数据行
-	for _, row := range m.OriginalRows {
+	// 写入数据行，按列格式输出
+	for _, row := range rows {
 		var values []string
-		for _, cell := range row {
-			// 处理CSV特殊字符，如逗号、引号等
-			cellStr := fmt.Sprintf("%v", cell)
-			if strings.Contains(cellStr, ",") || strings.Contains(cellStr, "\"") || strings.Contains(cellStr, "\n") {
-				cellStr = "\"" + strings.ReplaceAll(cellStr, "\"", "\"\"") + "\""
-			}
-			values = append(values, cellStr)
+		for i, cell := range row {
+			values = append(values, m.columnSpec(i).FormatCell(cell))
 		}
-		fmt.Fprintln(f, strings.Join(values, ","))
+		fmt.Fprint(f, csvLine(values))
 	}
 
 	return nil
 }
 
+// csvLine 将一行值拼接为 CSV 格式，含逗号、引号或换行的值加引号转义
+func csvLine(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		if strings.Contains(value, ",") || strings.Contains(value, "\"") || strings.Contains(value, "\n") {
+			value = "\"" + strings.ReplaceAll(value, "\"", "\"\"") + "\""
+		}
+		quoted[i] = value
+	}
+	return strings.Join(quoted, ",") + "\n"
+}
+
+// ExportPath 返回 CSV 导出文件的路径
+func (m TableModel) ExportPath() string {
+	return filepath.Join(m.exportDir(), "查询结果.csv")
+}
+
+// exportDir 返回导出目录，未设置时使用默认目录
+func (m TableModel) exportDir() string {
+	if m.ExportDir == "" {
+		return DefaultExportDir
+	}
+	return m.ExportDir
+}
+
 // GetDefaultTableStyles 返回默认的表格样式
 func GetDefaultTableStyles() table.Styles {
 	s := table.DefaultStyles()
@@ -251,188 +531,398 @@ func GetDefaultTableStyles() table.Styles {
 	return s
 }
 
-// Init 实现 tea.Model 接口
+// Init 实现 tea.Model 接口，数据源尚未读取时开始读取第一页，有待执行的排序/筛选时开始执行
 func (m TableModel) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.awaitingData() && m.Querying {
+		cmds = append(cmds, msgCmd(loadRequestMsg{}))
+	}
+	if m.Source != nil && !m.sourceDone {
+		cmds = append(cmds, msgCmd(sourceReloadMsg{}))
+	}
+	if m.viewPending {
+		cmds = append(cmds, msgCmd(refreshViewMsg{}))
+	}
+	if m.stream != nil {
+		cmds = append(cmds, m.waitStream(), streamTick())
+	}
+	cmds = append(cmds, m.refreshTick())
+	return tea.Batch(cmds...)
 }
 
 // Update 实现 tea.Model 接口
+// 组件不会直接退出程序，而是通过 RowSelectedMsg、FilterChangedMsg、SortChangedMsg、
+// QuitRequestedMsg 等消息通知外层，便于嵌入到更大的 Bubble Tea 应用中
 func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// 未获得焦点时不处理按键
+	if _, ok := msg.(tea.KeyMsg); ok && !m.Focused() {
+		return m, nil
+	}
+
+	before := m
+	// 排序栈可能被原地修改，先复制一份用于比较
+	before.SortKeys = append([]SortKey(nil), m.SortKeys...)
+	m, cmd := m.update(msg)
+	// 筛选栏的错误提示和状态消息会占用表格的高度
+	if m.layoutHeight() {
+		m.EnsureCursorVisible()
+	}
+	cmds := append([]tea.Cmd{cmd}, m.changeEvents(before)...)
+
+	// 排序或筛选下推给数据源时重新读取，否则按光标位置预读下一页
+	if reload := m.syncSource(before); reload != nil {
+		cmds = append(cmds, reload)
+	} else {
+		cmds = append(cmds, m.maybeLoadMore())
+	}
+	// 数据量大时排序和筛选在后台执行
+	cmds = append(cmds, m.startViewJob())
+	// 数据量大时搜索也在后台执行
+	cmds = append(cmds, m.startSearchJob())
+	return m, tea.Batch(cmds...)
+}
+
+// update 处理消息并更新模型状态
+func (m TableModel) update(msg tea.Msg) (TableModel, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// 查询结果返回之前只响应取消、重试和退出
+		if m.awaitingData() {
+			return m, m.updateLoading(msg)
+		}
+
+		// 单元格查看器和记录详情打开时按键作用于它们
+		if m.inspector != nil {
+			m.updateInspector(msg)
+			return m, nil
+		}
+		if m.detail != nil {
+			return m, m.updateDetail(msg)
+		}
+		if m.stats != nil {
+			return m, m.updateStats(msg)
+		}
+		if m.freq != nil {
+			return m, m.updateFrequency(msg)
+		}
+
 		// 如果正在过滤状态，使用textinput处理输入
 		if m.Filtering {
 			switch msg.String() {
 			case "enter":
-				// 确认过滤
+				// 确认过滤，新条件叠加在已有条件之上
+				if value := m.TextInput.Value(); strings.TrimSpace(value) != "" {
+					// 表达式有误时停留在筛选栏，显示错误位置
+					if err := m.AddFilter(value); err != nil {
+						m.FilterErr = err
+						return m, nil
+					}
+					m.setInfoStatus(fmt.Sprintf("筛选结果: 共 %d 个条件，找到 %d 行匹配数据",
+						len(m.Filters), len(m.OriginalRows)))
+				}
 				m.Filtering = false
-				// 应用过滤
-				m.FilterText = m.TextInput.Value()
-				m.ApplyFilter()
+				m.FilterErr = nil
 				m.TextInput.Reset()
 			case "esc":
-				// 取消过滤
+				// 取消输入，保留已有筛选条件
 				m.Filtering = false
-				m.FilterText = ""
+				m.FilterErr = nil
 				m.TextInput.Reset()
-				// 恢复原始数据
-				if len(m.AllRows) > 0 {
-					m.OriginalRows = make([]table.Row, len(m.AllRows))
-					copy(m.OriginalRows, m.AllRows)
-				}
-				m.UpdateVisibleColumns()
 			default:
 				// 使用textinput模型处理输入
 				var inputCmd tea.Cmd
 				m.TextInput, inputCmd = m.TextInput.Update(msg)
+				m.validateFilterInput()
 				return m, inputCmd
 			}
 			return m, nil
 		}
 
+		// 搜索输入状态：边输入边查找并高亮
+		if m.Searching {
+			switch msg.String() {
+			case "enter":
+				// 确认搜索，保留高亮，可用 n/N 跳转
+				m.Searching = false
+				m.SearchInput.Blur()
+			case "esc":
+				// 取消搜索
+				m.Searching = false
+				m.SearchInput.Blur()
+				m.ClearSearch()
+			default:
+				var inputCmd tea.Cmd
+				m.SearchInput, inputCmd = m.SearchInput.Update(msg)
+				m.SetSearch(m.SearchInput.Value())
+				return m, inputCmd
+			}
+			return m, nil
+		}
+
+		// 任意键关闭修改前后对比
+		if m.ShowDiffDetail {
+			m.ShowDiffDetail = false
+			return m, nil
+		}
+
+		// 等待选择导出/复制范围
+		if m.ScopePrompt != scopeNone {
+			m.updateScopePrompt(msg)
+			return m, nil
+		}
+
+		// 选择要移除的筛选条件
+		if m.PickingFilter {
+			switch msg.String() {
+			case "left":
+				if m.FilterChipCursor > 0 {
+					m.FilterChipCursor--
+				}
+			case "right":
+				if m.FilterChipCursor < len(m.Filters)-1 {
+					m.FilterChipCursor++
+				}
+			case "enter", "x", "delete", "backspace":
+				m.RemoveFilter(m.FilterChipCursor)
+				if m.FilterChipCursor >= len(m.Filters) {
+					m.FilterChipCursor = len(m.Filters) - 1
+				}
+				m.PickingFilter = len(m.Filters) > 0
+			case "esc":
+				m.PickingFilter = false
+			}
+			return m, nil
+		}
+
+		// 分组模式下移动光标和展开/折叠作用于分组视图
+		if m.grouping != nil && m.updateGrouping(msg) {
+			return m, nil
+		}
+
 		// 非过滤状态下的键盘操作
 		switch {
+		case m.viewJob != nil && key.Matches(msg, m.Keys.Cancel):
+			m.CancelViewJob()
+		case m.Querying && key.Matches(msg, m.Keys.Cancel):
+			m.CancelLoad()
 		case key.Matches(msg, m.Keys.Quit):
-			return m, tea.Quit
+			return m, msgCmd(QuitRequestedMsg{})
 		case key.Matches(msg, m.Keys.Help):
 			m.ShowHelp = !m.ShowHelp
 		case key.Matches(msg, m.Keys.Sort):
-			// 排序功能
-			// 当前显示的列的索引就是ScrollOffset
-			targetColumn := m.ScrollOffset
-			m.SortColumn = targetColumn
-			m.SortAsc = !m.SortAsc
-			m.SortRows()
+			// 排序功能，作用于光标所在列
+			m.ToggleSort(m.ColCursor, false)
 
 			// 确保光标可见
 			m.EnsureCursorVisible()
+		case key.Matches(msg, m.Keys.SortAdd):
+			// 将光标所在列加入排序栈
+			m.ToggleSort(m.ColCursor, true)
+			m.EnsureCursorVisible()
 		case key.Matches(msg, m.Keys.Filter):
-			// 开始过滤
-			// 当前显示的列的索引就是ScrollOffset
-			targetColumn := m.ScrollOffset
+			// 开始过滤，作用于光标所在列
 			m.Filtering = true
-			m.FilterText = ""
 			m.TextInput.Reset()
 			m.TextInput.Focus()
 			if len(m.AllRows) == 0 && len(m.OriginalRows) > 0 {
 				m.AllRows = make([]table.Row, len(m.OriginalRows))
 				copy(m.AllRows, m.OriginalRows)
 			}
-			m.SortColumn = targetColumn
+			m.FilterColumn = m.ColCursor
 			return m, textinput.Blink
+		case m.LoadErr != nil && m.Source != nil && key.Matches(msg, m.Keys.Retry):
+			// 重新读取加载失败的一页
+			m.LoadErr = nil
+			m.StatusMsg = ""
+			return m, m.loadMore()
+		case m.loader != nil && key.Matches(msg, m.Keys.Refresh):
+			return m, m.Refresh()
+		case m.diff != nil && key.Matches(msg, m.Keys.DiffOnly):
+			m.ToggleDiffOnly()
+		case m.diff != nil && key.Matches(msg, m.Keys.DiffDetail):
+			m.ShowDiffDetail = m.RowCount > 0
 		case key.Matches(msg, m.Keys.Reset):
-			if len(m.AllRows) > 0 {
-				m.OriginalRows = make([]table.Row, len(m.AllRows))
-				copy(m.OriginalRows, m.AllRows)
-				m.FilterText = ""
-				m.StatusMsg = "已重置筛选器，恢复全部数据"
-				m.UpdateVisibleColumns()
+			if len(m.Filters) > 0 {
+				m.ClearFilters()
+				m.setInfoStatus("已重置筛选器，恢复全部数据")
 			}
+		case key.Matches(msg, m.Keys.Unfilter):
+			m.RemoveFilter(len(m.Filters) - 1)
+		case key.Matches(msg, m.Keys.PickChip):
+			if len(m.Filters) > 0 {
+				m.PickingFilter = true
+				m.FilterChipCursor = len(m.Filters) - 1
+			}
+		case key.Matches(msg, m.Keys.Select) && m.selectionEnabled():
+			// 切换选中状态后下移一行，便于连续选择
+			m.ToggleSelection()
+			m.Cursor.Move(1)
+			m.EnsureCursorVisible()
+			return m, nil
+		case key.Matches(msg, m.Keys.SelectDown) && m.selectionEnabled():
+			m.ExtendSelection(1)
+			m.EnsureCursorVisible()
+			return m, nil
+		case key.Matches(msg, m.Keys.SelectUp) && m.selectionEnabled():
+			m.ExtendSelection(-1)
+			m.EnsureCursorVisible()
+			return m, nil
+		case key.Matches(msg, m.Keys.SelectAll) && m.selectionEnabled():
+			m.SelectAll()
+		case key.Matches(msg, m.Keys.Invert) && m.selectionEnabled():
+			m.InvertSelection()
+		case key.Matches(msg, m.Keys.Deselect):
+			m.ClearSelection()
+		case key.Matches(msg, m.Keys.Inspect):
+			m.OpenInspector()
+		case key.Matches(msg, m.Keys.Stats):
+			cmd = m.OpenStats()
+		case key.Matches(msg, m.Keys.Frequency):
+			cmd = m.OpenFrequency()
+		case key.Matches(msg, m.Keys.Group):
+			m.ToggleGroupColumn()
+		case key.Matches(msg, m.Keys.Pane):
+			m.TogglePane()
+		case !m.PickMode && key.Matches(msg, m.Keys.Detail):
+			// 选择器模式下回车用于确认选择；其他模式下回车打开详情，与确认键相同时仍通知宿主
+			m.OpenDetail()
+			if key.Matches(msg, m.Keys.Confirm) {
+				if rows := m.SelectedRows(); len(rows) > 0 {
+					cmd = msgCmd(RowSelectedMsg{Rows: rows})
+				}
+			}
+		case key.Matches(msg, m.Keys.Confirm):
+			if rows := m.SelectedRows(); len(rows) > 0 {
+				return m, msgCmd(RowSelectedMsg{Rows: rows})
+			}
+			return m, nil
+		case key.Matches(msg, m.Keys.Search):
+			m.StartSearch()
+			return m, textinput.Blink
+		case key.Matches(msg, m.Keys.NextHit):
+			m.NextMatch(true)
+		case key.Matches(msg, m.Keys.PrevHit):
+			m.NextMatch(false)
 		case key.Matches(msg, m.Keys.Export):
-			err := m.ExportToCSV()
-			if err != nil {
-				m.StatusMsg = fmt.Sprintf("导出失败: %v", err)
-			} else {
-				m.StatusMsg = "导出成功: ./output/查询结果.csv"
+			// 分组时询问导出汇总还是明细，有选中行时询问导出范围
+			if m.grouping != nil {
+				m.ScopePrompt = scopeGroupExport
+				m.StatusMsg = "导出分组: g 分组汇总 | d/s 明细行 | 其他键取消"
+				return m, nil
+			}
+			if len(m.Selected) > 0 {
+				m.ScopePrompt = scopeExport
+				m.StatusMsg = fmt.Sprintf("导出范围: s 仅选中行 (%d) | a 全部行 | 其他键取消", len(m.Selected))
+				return m, nil
 			}
+			m.exportRows(m.displayedRows())
+		case key.Matches(msg, m.Keys.Copy):
+			// 有选中行时询问复制内容
+			if len(m.Selected) > 0 {
+				m.ScopePrompt = scopeCopy
+				m.StatusMsg = fmt.Sprintf("复制: s 选中行 (%d) | c 当前单元格 | 其他键取消", len(m.Selected))
+				return m, nil
+			}
+			m.copyCell()
 		case key.Matches(msg, m.Keys.Left):
-			if m.ScrollOffset > 0 {
-				currentCursor := m.Table.Cursor() // 保存光标
-				m.ScrollOffset--
-				m.UpdateVisibleColumns()
-				m.Table.SetCursor(currentCursor) // 恢复光标
-				m.EnsureCursorVisible()          // 确保可见
+			if m.ColCursor > 0 {
+				m.MoveColumnCursor(m.ColCursor - 1)
 			}
 		case key.Matches(msg, m.Keys.Right):
-			// 检查是否还可以向右滚动
-			if m.ScrollOffset < len(m.TableColumns)-1 {
-				currentCursor := m.Table.Cursor() // 保存光标
-				m.ScrollOffset++
-				m.UpdateVisibleColumns()
-				m.Table.SetCursor(currentCursor) // 恢复光标
-				m.EnsureCursorVisible()          // 确保可见
+			if m.ColCursor < len(m.TableColumns)-1 {
+				m.MoveColumnCursor(m.ColCursor + 1)
 			}
 		case key.Matches(msg, m.Keys.End):
-			// 计算最大滚动偏移，确保最后一列可见
-			maxScroll := len(m.TableColumns) - 1
-			if maxScroll < 0 {
-				maxScroll = 0
-			}
-			if m.ScrollOffset != maxScroll {
-				// 保存当前光标位置
-				currentCursor := m.Table.Cursor()
-				m.ScrollOffset = maxScroll
-				m.UpdateVisibleColumns()
-				// 恢复光标位置并确保可见
-				m.Table.SetCursor(currentCursor)
-				m.EnsureCursorVisible()
+			// 光标移动到最后一列
+			if len(m.TableColumns) > 0 && m.ColCursor != len(m.TableColumns)-1 {
+				m.MoveColumnCursor(len(m.TableColumns) - 1)
 			}
 		case key.Matches(msg, m.Keys.Home):
-			if m.ScrollOffset > 0 {
-				// 保存当前光标位置
-				currentCursor := m.Table.Cursor()
-				m.ScrollOffset = 0
-				m.UpdateVisibleColumns()
-				// 恢复光标位置并确保可见
-				m.Table.SetCursor(currentCursor)
-				m.EnsureCursorVisible()
+			// 光标移动到第一列
+			if m.ColCursor > 0 {
+				m.MoveColumnCursor(0)
 			}
+		case key.Matches(msg, m.Keys.Up):
+			m.Cursor.Move(-1)
+		case key.Matches(msg, m.Keys.Down):
+			m.Cursor.Move(1)
 		case key.Matches(msg, m.Keys.PageUp):
-			pageSize := m.Height / 2
-
-			// 通过多次发送up键实现翻页
-			for i := 0; i < pageSize; i++ {
-				upMsg := tea.KeyMsg{Type: tea.KeyUp}
-				newTable, _ := m.Table.Update(upMsg)
-				m.Table = newTable
-			}
-
+			m.Cursor.Move(-m.Height / 2)
 		case key.Matches(msg, m.Keys.PageDown):
-			pageSize := m.Height / 2
-
-			// 通过多次发送down键实现翻页
-			for i := 0; i < pageSize; i++ {
-				downMsg := tea.KeyMsg{Type: tea.KeyDown}
-				newTable, _ := m.Table.Update(downMsg)
-				m.Table = newTable
+			m.Cursor.Move(m.Height / 2)
+		case m.Cursor.Update(msg, m.bodyHeight()):
+		default:
+			// 调用方注册的批量操作
+			if actionCmd, ok := m.runBulkAction(msg); ok {
+				return m, actionCmd
 			}
 		}
 	case tea.WindowSizeMsg:
-		h, v := baseStyle.GetFrameSize()
-		m.Width = msg.Width - h
-		// 预留空间给标题、信息行和帮助文本
-		reservedHeight := 4 // 标题、信息行、状态消息、帮助文本等
-		if m.Filtering {
-			reservedHeight++
+		m.SetSize(msg.Width, msg.Height)
+	case rowsLoadedMsg:
+		m.handleRowsLoaded(msg)
+	case sourceReloadMsg:
+		return m, m.reloadSource()
+	case viewComputedMsg:
+		m.handleViewComputed(msg)
+	case searchDoneMsg:
+		m.handleSearchDone(msg)
+	case statsComputedMsg:
+		m.handleStatsComputed(msg)
+	case freqComputedMsg:
+		m.handleFreqComputed(msg)
+	case streamRowsMsg:
+		cmd = m.handleStreamRows(msg)
+	case streamTickMsg:
+		if m.stream != nil {
+			m.QueryDuration = time.Since(m.stream.start).Truncate(time.Second)
+			cmd = streamTick()
 		}
-		if m.FilterText != "" {
-			reservedHeight++
+	case loadRequestMsg:
+		cmd = m.startLoad()
+	case dataLoadedMsg:
+		cmd = m.handleDataLoaded(msg)
+	case refreshTickMsg:
+		cmd = m.handleRefreshTick()
+	case clearChangesMsg:
+		if msg.gen == m.changeGen {
+			m.changedCells = nil
 		}
-		if m.StatusMsg != "" {
-			reservedHeight++
+	case spinner.TickMsg:
+		if m.viewJob == nil && !m.Querying && !m.statsComputing() && m.searchJob == nil {
+			return m, nil
 		}
-		m.Height = msg.Height - v - reservedHeight
-		m.Table.SetWidth(m.Width)
-		m.Table.SetHeight(m.Height)
-		m.CalculateMaxColumns()
-		m.UpdateVisibleColumns()
-	}
-
-	newTable, newCmd := m.Table.Update(msg)
-	m.Table = newTable
-	if newCmd != nil {
-		cmd = newCmd
+		if m.Querying {
+			m.QueryDuration = time.Since(m.loadStart)
+		}
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
 	}
 
 	m.EnsureCursorVisible()
+	m.followGroupCursor()
 
 	return m, cmd
 }
 
 // View 实现 tea.Model 接口
 func (m TableModel) View() string {
+	if m.awaitingData() {
+		return m.loadingView()
+	}
+	if m.inspector != nil {
+		return m.inspectorView()
+	}
+	if m.detail != nil && !m.detailInPane() {
+		return m.detailView()
+	}
+	// 调用方可能在两次 Update 之间修改状态消息，按本次要渲染的内容确定表格高度
+	if m.layoutHeight() {
+		m.EnsureCursorVisible()
+	}
+
 	var b strings.Builder
 
 	// 标题行和查询时间
@@ -441,7 +931,10 @@ func (m TableModel) View() string {
 		queryDuration = m.QueryDuration.String()
 	}
 	titleInfo := fmt.Sprintf("%s | 查询耗时: %v", m.Title, queryDuration)
-	b.WriteString(titleStyle.Render(titleInfo))
+	if status := m.streamStatus(); status != "" {
+		titleInfo += " | " + status
+	}
+	b.WriteString(m.Theme.Title.Render(titleInfo))
 	b.WriteString("\n")
 
 	// 计算列信息
@@ -451,108 +944,267 @@ func (m TableModel) View() string {
 	}
 
 	// 合并行列和排序信息到一行
-	currentRow := m.Table.Cursor() + 1
+	currentRow := m.Cursor.Index() + 1
 	var navigationInfo string
 
-	if m.Filtering {
+	if m.Searching {
+		// 搜索状态下显示搜索栏和命中计数
+		b.WriteString(m.Theme.SearchBar.Render("搜索: "))
+		b.WriteString(m.SearchInput.View())
+		b.WriteString(m.Theme.Info.Render(m.searchStatus()))
+	} else if m.Filtering {
 		// 在筛选状态下显示筛选信息而不是导航信息
-		var columnName string
-		if m.SortColumn < len(m.TableColumns) {
-			columnName = m.TableColumns[m.SortColumn].Title
-		} else {
-			columnName = fmt.Sprintf("第%d列", m.SortColumn+1)
-		}
+		columnName := m.columnName(m.FilterColumn)
 
 		filterInfo := fmt.Sprintf("筛选中 (列: %s): ", columnName)
-		b.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
-			Bold(true).
-			MarginLeft(2).
-			Render(filterInfo))
-
-		inputStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("205")).
-			Background(lipgloss.Color("0")).
-			Bold(true)
-
-		b.WriteString(inputStyle.Render(m.TextInput.View()))
+		b.WriteString(m.Theme.FilterBar.Render(filterInfo))
+		b.WriteString(m.Theme.FilterInput.Render(m.TextInput.View()))
+
+		// 表达式错误：在出错位置下方标出 ^ 并显示原因
+		var filterErr *FilterError
+		if errors.As(m.FilterErr, &filterErr) {
+			value := m.TextInput.Value()
+			pos := filterErr.Pos
+			if pos > len(value) {
+				pos = len(value)
+			}
+			offset := DisplayWidth(filterInfo) + DisplayWidth(m.TextInput.Prompt) + DisplayWidth(value[:pos])
+			b.WriteString("\n")
+			b.WriteString(m.Theme.Error.Render(strings.Repeat(" ", offset) + "^ " + filterErr.Error()))
+		}
 	} else {
 		// 非筛选状态下显示常规导航信息和筛选结果
-		if m.SortColumn >= 0 {
-			var columnName string
-			if m.SortColumn < len(m.TableColumns) {
-				columnName = m.TableColumns[m.SortColumn].Title
-			} else {
-				columnName = fmt.Sprintf("第%d列", m.SortColumn+1)
-			}
-			navigationInfo = fmt.Sprintf("行: %d/%d (%.1f%%) | 列: %d-%d/%d | 排序: %s (%s)",
-				currentRow, m.RowCount, float64(currentRow)*100/float64(m.RowCount),
-				m.ScrollOffset+1, m.ScrollOffset+m.MaxColumns, len(m.TableColumns),
-				columnName,
-				map[bool]string{true: "升序", false: "降序"}[m.SortAsc])
-		} else {
-			navigationInfo = fmt.Sprintf("行: %d/%d (%.1f%%) | 列: %d-%d/%d",
-				currentRow, m.RowCount, float64(currentRow)*100/float64(m.RowCount),
-				m.ScrollOffset+1, m.ScrollOffset+m.MaxColumns, len(m.TableColumns))
-		}
-
-		// 如果有筛选结果，添加到导航信息后面
-		if m.FilterText != "" {
-			var columnName string
-			if m.SortColumn < len(m.TableColumns) {
-				columnName = m.TableColumns[m.SortColumn].Title
-			} else {
-				columnName = fmt.Sprintf("第%d列", m.SortColumn+1)
-			}
-			filterInfo := fmt.Sprintf(" | 筛选: %s=\"%s\" (%d行)",
-				columnName, m.FilterText, len(m.OriginalRows))
-			navigationInfo += filterInfo
+		// 有筛选条件时行号相对筛选结果，并附带总行数
+		shownRows := m.RowCount
+		if len(m.Filters) > 0 {
+			shownRows = len(m.OriginalRows)
 		}
+		percent := 0.0
+		if shownRows > 0 {
+			percent = float64(currentRow) * 100 / float64(shownRows)
+		}
+		navigationInfo = fmt.Sprintf("行: %d/%d (%.1f%%)", currentRow, shownRows, percent)
+		if len(m.Filters) > 0 {
+			navigationInfo += fmt.Sprintf(" 共 %d 行", m.RowCount)
+		}
+		navigationInfo += fmt.Sprintf(" | 列: %d/%d [%s] (可见 %d-%d)",
+			m.ColCursor+1, len(m.TableColumns), m.columnName(m.ColCursor),
+			m.ScrollOffset+1, m.ScrollOffset+m.MaxColumns)
+		if len(m.SortKeys) > 0 {
+			navigationInfo += " | 排序: " + m.sortDescription()
+		}
+		if m.SearchText != "" {
+			navigationInfo += fmt.Sprintf(" | 搜索: %q %s", m.SearchText, m.searchStatus())
+		}
+		if len(m.Selected) > 0 {
+			navigationInfo += fmt.Sprintf(" | 已选: %d 行", len(m.Selected))
+		}
+		if status := m.loadStatus(); status != "" {
+			navigationInfo += " | " + status
+		}
+		if status := m.viewJobStatus(); status != "" {
+			navigationInfo += " | " + status
+		}
+		if status := m.refreshStatus(); status != "" {
+			navigationInfo += " | " + status
+		}
+		if status := m.diffStatus(); status != "" {
+			navigationInfo += " | " + status
+		}
+		if status := m.groupStatus(); status != "" {
+			navigationInfo += " | " + status
+		}
+
+		b.WriteString(m.Theme.Info.Render(navigationInfo))
 
-		b.WriteString(infoStyle.Render(navigationInfo))
+		// 如果有筛选条件，以标签形式显示在导航信息后面
+		if len(m.Filters) > 0 {
+			b.WriteString(m.Theme.Info.UnsetMarginLeft().Render(" | 筛选: "))
+			b.WriteString(m.renderFilterChips())
+			if m.PickingFilter {
+				b.WriteString(m.Theme.Info.UnsetMarginLeft().Render(" ←/→ 选择 enter 移除 esc 取消"))
+			}
+		}
 	}
 	b.WriteString("\n")
 
-	// 状态消息（只显示非筛选相关的状态消息）
-	if m.StatusMsg != "" && !strings.Contains(m.StatusMsg, "筛选") && !strings.Contains(m.StatusMsg, "恢复全部数据") {
-		b.WriteString(statusStyle.Render(m.StatusMsg))
+	// 状态消息，信息行已经体现的不再重复显示
+	if m.statusVisible() {
+		b.WriteString(m.Theme.Status.Render(m.StatusMsg))
 		b.WriteString("\n")
 	}
 
-	// 表格内容
-	b.WriteString(baseStyle.Render(m.Table.View()))
+	// 表格内容，查看修改前后的值时显示对比
+	body := m.renderTable()
+	switch {
+	case m.stats != nil:
+		body = m.statsView()
+	case m.freq != nil:
+		body = m.frequencyView()
+	case m.ShowDiffDetail:
+		body = m.diffDetailView()
+	case m.grouping != nil:
+		body = m.groupedView()
+	}
+	body = m.Theme.Base.Render(body)
+	if m.paneVisible() {
+		// 表格补齐到可用宽度，预览栏贴在右侧
+		body = lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.PlaceHorizontal(m.Width, lipgloss.Left, body), m.renderPane())
+	}
+	b.WriteString(body)
 
-	// 计算剩余空间并添加填充
-	_, height, _ := term.GetSize(int(os.Stdout.Fd()))
+	// 计算剩余空间并添加填充，使帮助信息贴底
 	currentHeight := strings.Count(b.String(), "\n") // the help text and margins
-	if height > currentHeight {
-		padding := height - currentHeight - 1 // -1 to leave room for help text
+	if m.viewHeight > currentHeight {
+		padding := m.viewHeight - currentHeight - 1 // -1 to leave room for help text
 		b.WriteString(strings.Repeat("\n", padding))
 	}
 
-	// 帮助信息
-	if m.ShowHelp {
+	// 帮助信息，预览栏中打开记录详情时显示详情的按键
+	if m.detail != nil {
+		b.WriteString(m.Theme.Help.Render(m.detailHelp()))
+	} else if m.ShowHelp {
 		// 将所有帮助信息合并为一行
 		var helpBindings []string
-		for _, bindings := range m.Keys.FullHelp() {
+		for _, bindings := range append(m.availableKeys().FullHelp(), m.bulkActionHelp()) {
 			for _, binding := range bindings {
 				keys := binding.Help().Key
 				desc := binding.Help().Desc
-				if keys != "" && desc != "" {
+				if binding.Enabled() && keys != "" && desc != "" {
 					helpBindings = append(helpBindings, fmt.Sprintf("%s %s", keys, desc))
 				}
 			}
 		}
 		helpText := strings.Join(helpBindings, " | ")
-		b.WriteString(helpStyle.Render(helpText))
+		b.WriteString(m.Theme.Help.Render(helpText))
 	} else {
-		helpText := "按 h 显示帮助 | Shift+←/→ 首/末列 | Shift+↑/↓ 行翻页 | s 排序当前列 | f 筛选当前列 | r 重置 | e 导出 | esc 退出"
-		b.WriteString(helpStyle.Render(helpText))
+		helpText := "按 h 显示帮助"
+		if m.PickMode {
+			helpText = "enter 确认选择 | " + helpText
+			if m.MultiSelect {
+				helpText = "space 多选 | " + helpText
+			}
+		}
+		helpText += " | " + m.shortHelp()
+		b.WriteString(m.Theme.Help.Render(helpText))
 	}
 
 	return b.String()
 }
 
+// setInfoStatus 设置内容已由信息行体现的状态消息，如筛选结果的行数，之后设置的其他消息照常显示
+func (m *TableModel) setInfoStatus(msg string) {
+	m.StatusMsg = msg
+	m.infoStatus = msg
+}
+
+// statusVisible 状态消息是否需要单独显示一行
+func (m TableModel) statusVisible() bool {
+	return m.StatusMsg != "" && m.StatusMsg != m.infoStatus
+}
+
+// shortHelp 返回底部常驻的简短帮助，按当前键位生成并略过已禁用的按键
+func (m TableModel) shortHelp() string {
+	items := []struct {
+		bindings []key.Binding
+		desc     string
+	}{
+		{[]key.Binding{m.Keys.Home, m.Keys.End}, "首/末列"},
+		{[]key.Binding{m.Keys.PageUp, m.Keys.PageDown}, "行翻页"},
+		{[]key.Binding{m.Keys.Left, m.Keys.Right}, "移动列光标"},
+		{[]key.Binding{m.Keys.Sort}, "排序当前列"},
+		{[]key.Binding{m.Keys.Filter}, "筛选当前列"},
+		{[]key.Binding{m.Keys.Copy}, "复制"},
+		{[]key.Binding{m.Keys.Reset}, "重置"},
+		{[]key.Binding{m.Keys.Export}, "导出"},
+		{[]key.Binding{m.Keys.Quit}, "退出"},
+	}
+
+	var parts []string
+	for _, item := range items {
+		var keys []string
+		for _, binding := range item.bindings {
+			if binding.Enabled() {
+				keys = append(keys, binding.Help().Key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		if len(keys) == 2 {
+			keys = []string{joinKeyPair(keys[0], keys[1])}
+		}
+		parts = append(parts, keys[0]+" "+item.desc)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// joinKeyPair 合并一对按键的显示文本，共同的修饰键只写一次，如 Shift+←/→
+func joinKeyPair(a, b string) string {
+	prefix := ""
+	if i := strings.LastIndex(a, "+"); i >= 0 && strings.HasPrefix(b, a[:i+1]) {
+		prefix = a[:i+1]
+	}
+	return prefix + a[len(prefix):] + "/" + b[len(prefix):]
+}
+
+// SetSize 设置组件占用的总尺寸（包括标题、信息行和帮助信息），表格区域按剩余空间计算
+func (m *TableModel) SetSize(width, height int) {
+	m.viewWidth = width
+	m.viewHeight = height
+
+	h, _ := m.Theme.Base.GetFrameSize()
+	m.Width = width - h - m.paneWidth()
+	if m.ShowPane && !m.paneVisible() {
+		m.StatusMsg = paneHiddenStatus
+	} else if m.StatusMsg == paneHiddenStatus {
+		m.StatusMsg = ""
+	}
+	m.layoutHeight()
+	m.EnsureColumnVisible()
+	m.UpdateVisibleColumns()
+	m.EnsureCursorVisible()
+}
+
+// chromeHeight 返回 View 在表格之外渲染的行数：标题、信息行、筛选表达式错误、状态消息和帮助信息
+func (m TableModel) chromeHeight() int {
+	lines := 3 // 标题、信息行和帮助信息
+	var filterErr *FilterError
+	if m.Filtering && errors.As(m.FilterErr, &filterErr) {
+		lines++
+	}
+	if m.statusVisible() {
+		lines += lipgloss.Height(m.StatusMsg)
+	}
+	return lines
+}
+
+// layoutHeight 按 View 实际渲染的内容计算表格区域的高度，返回高度是否变化
+func (m *TableModel) layoutHeight() bool {
+	_, v := m.Theme.Base.GetFrameSize()
+	height := max(m.viewHeight-v-m.chromeHeight(), 2)
+	if height == m.Height {
+		return false
+	}
+	m.Height = height
+	return true
+}
+
+// Focus 使组件获得焦点，开始响应按键
+func (m *TableModel) Focus() {
+	m.Cursor.Focus()
+}
+
+// Blur 使组件失去焦点，不再响应按键
+func (m *TableModel) Blur() {
+	m.Cursor.Blur()
+}
+
+// Focused 返回组件是否拥有焦点
+func (m TableModel) Focused() bool {
+	return m.Cursor.Focused()
+}
+
 // CalculateMaxColumns 计算当前可以显示的最大列数
 func (m *TableModel) CalculateMaxColumns() {
 	if len(m.TableColumns) == 0 {
@@ -582,9 +1234,10 @@ func (m *TableModel) CalculateMaxColumns() {
 	m.MaxColumns = count
 }
 
-// UpdateVisibleColumns 更新可见列
+// UpdateVisibleColumns 更新可见列范围和行光标的行数，开销与数据行数无关
 func (m *TableModel) UpdateVisibleColumns() {
 	if len(m.TableColumns) == 0 {
+		m.Cursor.SetLen(len(m.OriginalRows))
 		return
 	}
 
@@ -608,302 +1261,151 @@ func (m *TableModel) UpdateVisibleColumns() {
 		m.ScrollOffset = newOffset
 	}
 
-	endIdx := m.ScrollOffset + m.MaxColumns
-	if endIdx > len(m.TableColumns) {
-		endIdx = len(m.TableColumns)
-	}
-
-	// 保存当前光标位置、焦点状态和表格尺寸
-	currentCursor := m.Table.Cursor()
-	isFocused := m.Table.Focused()
-	tableWidth := m.Width
-	tableHeight := m.Height
-
-	visibleColumns := m.TableColumns[m.ScrollOffset:endIdx]
+	// 数据行不做投影，渲染时只格式化可见窗口内的单元格
+	m.Cursor.SetLen(len(m.OriginalRows))
+}
 
-	visibleRows := make([]table.Row, len(m.OriginalRows))
-	for i, row := range m.OriginalRows {
-		if i < len(visibleRows) && m.ScrollOffset < len(row) {
-			visibleRow := make(table.Row, len(visibleColumns))
-			for j := range visibleColumns {
-				colIdx := m.ScrollOffset + j
-				if colIdx < len(row) {
-					visibleRow[j] = row[colIdx]
-				} else {
-					visibleRow[j] = ""
-				}
-			}
-			visibleRows[i] = visibleRow
-		}
+// MoveColumnCursor 将列光标移动到指定列，仅当光标离开可见窗口时才滚动
+func (m *TableModel) MoveColumnCursor(col int) {
+	m.ColCursor = col
+	if m.EnsureColumnVisible() {
+		m.UpdateVisibleColumns()
 	}
+}
 
-	newTable := table.New(
-		table.WithColumns(visibleColumns),
-		table.WithRows(visibleRows),
-		table.WithFocused(isFocused),
-		table.WithHeight(tableHeight),
-	)
-
-	// 确保表格尺寸保持一致
-	newTable.SetWidth(tableWidth)
-	newTable.SetHeight(tableHeight)
-
-	newTable.SetStyles(GetDefaultTableStyles())
-
-	// 设置光标位置并确保可见
-	if len(visibleRows) > 0 {
-		validCursor := currentCursor
-		if validCursor < 0 {
-			validCursor = 0
-		} else if validCursor >= len(visibleRows) {
-			validCursor = len(visibleRows) - 1
-		}
-		newTable.SetCursor(validCursor)
+// EnsureColumnVisible 调整横向滚动偏移使光标列可见，返回偏移是否发生变化
+func (m *TableModel) EnsureColumnVisible() bool {
+	if len(m.TableColumns) == 0 {
+		m.ColCursor = 0
+		return false
+	}
+	if m.ColCursor < 0 {
+		m.ColCursor = 0
+	} else if m.ColCursor >= len(m.TableColumns) {
+		m.ColCursor = len(m.TableColumns) - 1
 	}
 
-	m.Table = newTable
-
-	// 使用更直接的方法确保光标刷新和可见
-	// 如果有行数，根据光标位置选择不同的刷新策略
-	if len(m.Table.Rows()) > 0 {
-		currentCursor := m.Table.Cursor()
-
-		if currentCursor == 0 {
-			// 如果光标在第一行，先下移再上移
-			downMsg := tea.KeyMsg{Type: tea.KeyDown}
-			newTable, _ := m.Table.Update(downMsg)
-			m.Table = newTable
-
-			upMsg := tea.KeyMsg{Type: tea.KeyUp}
-			newTable, _ = m.Table.Update(upMsg)
-			m.Table = newTable
-		} else if currentCursor == len(m.Table.Rows())-1 {
-			// 如果光标在最后一行，先上移再下移
-			upMsg := tea.KeyMsg{Type: tea.KeyUp}
-			newTable, _ := m.Table.Update(upMsg)
-			m.Table = newTable
-
-			downMsg := tea.KeyMsg{Type: tea.KeyDown}
-			newTable, _ = m.Table.Update(downMsg)
-			m.Table = newTable
-		} else {
-			// 如果光标在中间行，可以使用任意顺序
-			upMsg := tea.KeyMsg{Type: tea.KeyUp}
-			newTable, _ := m.Table.Update(upMsg)
-			m.Table = newTable
-
-			downMsg := tea.KeyMsg{Type: tea.KeyDown}
-			newTable, _ = m.Table.Update(downMsg)
-			m.Table = newTable
-		}
+	oldOffset := m.ScrollOffset
+	if m.ColCursor < m.ScrollOffset {
+		m.ScrollOffset = m.ColCursor
 	}
+	m.CalculateMaxColumns()
+	for m.ScrollOffset < m.ColCursor && m.ColCursor >= m.ScrollOffset+m.MaxColumns {
+		m.ScrollOffset++
+		m.CalculateMaxColumns()
+	}
+	return m.ScrollOffset != oldOffset
 }
 
-// EnsureCursorVisible 确保光标在可见范围内
-func (m *TableModel) EnsureCursorVisible() {
-	cursor := m.Table.Cursor()
-	rowCount := len(m.Table.Rows())
-
-	// 如果表格为空，不做任何操作
-	if rowCount == 0 {
-		return
+// SelectedCell 返回光标所在单元格的内容
+func (m TableModel) SelectedCell() string {
+	cursor := m.Cursor.Index()
+	if cursor >= len(m.OriginalRows) {
+		return ""
 	}
-
-	// 如果光标超出范围，重置到有效范围内
-	if cursor < 0 {
-		m.Table.SetCursor(0)
-		return
-	} else if cursor >= rowCount {
-		m.Table.SetCursor(rowCount - 1)
-		return
+	row := m.OriginalRows[cursor]
+	if m.ColCursor < 0 || m.ColCursor >= len(row) {
+		return ""
 	}
+	return row[m.ColCursor]
+}
 
-	// 即使在范围内，也检查是否真的有选中行
-	selectedRow := m.Table.SelectedRow()
-	if len(selectedRow) == 0 {
-		// 如果没有选中行（光标看不见），强制重新设置光标位置
-		// 首先尝试当前位置
-		m.Table.SetCursor(cursor)
-
-		// 如果仍然没有选中行，尝试移到第一行
-		selectedRow = m.Table.SelectedRow()
-		if len(selectedRow) == 0 && rowCount > 0 {
-			m.Table.SetCursor(0)
-		}
+// columnSpec 返回列定义，未定义时视为字符串列
+func (m TableModel) columnSpec(col int) ColumnSpec {
+	if col >= 0 && col < len(m.Columns) {
+		return m.Columns[col]
 	}
+	return ColumnSpec{Name: m.columnName(col)}
 }
 
-// SortRows 排序表格行
-func (m *TableModel) SortRows() {
-	if m.SortColumn < 0 {
-		return
+// columnSpecs 返回全部列定义，未设置时按列标题生成字符串列
+func (m TableModel) columnSpecs() []ColumnSpec {
+	if len(m.Columns) == len(m.TableColumns) {
+		return m.Columns
 	}
-
-	rows := make([]table.Row, len(m.OriginalRows))
-	copy(rows, m.OriginalRows)
-
-	sort.Slice(rows, func(i, j int) bool {
-		a, b := rows[i][m.SortColumn], rows[j][m.SortColumn]
-		aNum, aErr := strconv.ParseFloat(a, 64)
-		bNum, bErr := strconv.ParseFloat(b, 64)
-
-		if aErr == nil && bErr == nil {
-			if m.SortAsc {
-				return aNum < bNum
-			}
-			return aNum > bNum
-		}
-
-		if m.SortAsc {
-			return a < b
-		}
-		return a > b
-	})
-
-	m.OriginalRows = rows
-	m.UpdateVisibleColumns()
+	specs := make([]ColumnSpec, len(m.TableColumns))
+	for i := range specs {
+		specs[i] = m.columnSpec(i)
+	}
+	return specs
 }
 
-// ApplyFilter 应用过滤
-func (m *TableModel) ApplyFilter() {
-	if m.FilterText == "" {
-		if len(m.AllRows) > 0 {
-			m.OriginalRows = make([]table.Row, len(m.AllRows))
-			copy(m.OriginalRows, m.AllRows)
-		}
-		m.UpdateVisibleColumns()
-		m.StatusMsg = "已恢复全部数据"
-		return
+// columnName 返回列标题，越界时返回序号描述
+func (m TableModel) columnName(col int) string {
+	if col >= 0 && col < len(m.TableColumns) {
+		return m.TableColumns[col].Title
 	}
+	return fmt.Sprintf("第%d列", col+1)
+}
 
-	var filteredRows []table.Row
-	lowerFilterText := strings.ToLower(m.FilterText)
+// EnsureCursorVisible 确保光标在有效范围内，并让可见窗口跟随光标
+func (m *TableModel) EnsureCursorVisible() {
+	m.Cursor.SetLen(len(m.OriginalRows))
+	m.scrollToCursor()
+}
 
-	for _, row := range m.AllRows {
-		cellValue := strings.ToLower(row[m.SortColumn])
-		if strings.Contains(cellValue, lowerFilterText) {
-			filteredRows = append(filteredRows, row)
-		}
+// scrollToCursor 调整 RowOffset，使光标行落在可见窗口内
+func (m *TableModel) scrollToCursor() {
+	cursor := m.Cursor.Index()
+	height := m.bodyHeight()
+	if cursor < m.RowOffset {
+		m.RowOffset = cursor
+	} else if cursor >= m.RowOffset+height {
+		m.RowOffset = cursor - height + 1
+	}
+	if maxOffset := len(m.OriginalRows) - height; m.RowOffset > maxOffset {
+		m.RowOffset = maxOffset
+	}
+	if m.RowOffset < 0 {
+		m.RowOffset = 0
 	}
-
-	m.FilteredRows = filteredRows
-	m.OriginalRows = make([]table.Row, len(filteredRows))
-	copy(m.OriginalRows, filteredRows)
-
-	m.UpdateVisibleColumns()
-
-	columnName := m.TableColumns[m.SortColumn].Title
-	m.StatusMsg = fmt.Sprintf("筛选结果: 在列 [%s] 中找到 %d 行匹配数据",
-		columnName, len(filteredRows))
 }
 
 // TableData 表格数据结构
 type TableData struct {
 	Title    string            // 表格标题
 	Headers  []string          // 表头
+	Columns  []ColumnSpec      // 列定义（可选），未提供时根据数据推断类型
 	Rows     [][]string        // 数据行
+	Keys     []string          // 主键列名（可选），用于在排序、筛选、刷新前后识别同一条记录，未提供时按原始顺序识别
 	Metadata map[string]string // 元数据（可选）
 }
 
-// ShowTable 显示表格数据
-func ShowTable(data TableData) error {
-	// 创建表格列
-	tableColumns := make([]table.Column, len(data.Headers))
-	colMaxWidth := make([]int, len(data.Headers))
-
-	// 初始化列宽度
-	for i, header := range data.Headers {
-		colMaxWidth[i] = len(header) + 2
-	}
-
-	// 计算每列的最大宽度
-	for _, row := range data.Rows {
-		for i, cell := range row {
-			if i < len(colMaxWidth) && len(cell) > colMaxWidth[i] {
-				colMaxWidth[i] = len(cell) + 2
-			}
-		}
-	}
-
-	// 设置列属性
-	for i, header := range data.Headers {
-		width := colMaxWidth[i]
-		if width < 10 {
-			width = 10
-		} else if width > 40 {
-			width = 40
-		}
-		tableColumns[i] = table.Column{
-			Title: header,
-			Width: width,
-		}
-	}
-
-	// 转换数据行格式
-	tableRows := make([]table.Row, len(data.Rows))
-	for i, row := range data.Rows {
-		tableRows[i] = table.Row(row)
+// ShowTable 显示表格数据，可通过 Option 调整键位、配色、列宽、初始排序和筛选等
+func ShowTable(data TableData, opts ...Option) error {
+	cfg := newConfig(opts)
+	m, err := newTableModel(data, cfg)
+	if err != nil {
+		return err
 	}
 
-	// 如果没有数据，添加一个空行
-	if len(tableRows) == 0 {
-		emptyRow := make(table.Row, len(data.Headers))
-		for i := range emptyRow {
-			emptyRow[i] = "无数据"
-		}
-		tableRows = append(tableRows, emptyRow)
-	}
+	// 运行程序
+	_, err = runProgram(m, cfg.programOptions()...)
+	return err
+}
 
-	// 创建表格模型
-	m := NewTableModel()
-	m.Title = data.Title
-	m.RowCount = len(data.Rows)
-	m.OriginalRows = make([]table.Row, len(tableRows))
-	copy(m.OriginalRows, tableRows)
-	m.AllRows = make([]table.Row, len(tableRows))
-	copy(m.AllRows, tableRows)
-	m.TableColumns = tableColumns
+// NewTableModelFromData 根据表格数据构建可直接嵌入的表格模型，不启动程序
+// 默认尺寸为 80x24，嵌入时通过 SetSize 或转发 tea.WindowSizeMsg 调整
+func NewTableModelFromData(data TableData, opts ...Option) (TableModel, error) {
+	return newTableModel(data, newConfig(opts))
+}
 
-	// 设置表格尺寸
-	width, height, err := term.GetSize(int(os.Stdout.Fd()))
-	if err == nil {
-		m.Width = width - 4
-		m.Height = height - 12
-		if m.Height < 10 {
-			m.Height = 10
-		}
-	} else {
-		m.Width = 80
-		m.Height = 20
+// newTableModel 按配置构建表格模型，内存中的数据一次性全部载入
+func newTableModel(data TableData, cfg config) (TableModel, error) {
+	m := newSourceModel(data, cfg)
+	if m.Title == "" {
+		m.Title = data.Title
 	}
 
-	// 初始化表格
-	t := table.New(
-		table.WithColumns(tableColumns),
-		table.WithRows(tableRows),
-		table.WithFocused(true),
-		table.WithHeight(m.Height),
-	)
-
-	durationStr := data.Metadata["QueryDuration"]
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		// 处理错误
-		return err
+	// 元数据中的查询耗时是可选的，缺失或无法解析时不显示
+	if duration, err := time.ParseDuration(data.Metadata["QueryDuration"]); err == nil {
+		m.QueryDuration = duration
 	}
-	m.QueryDuration = duration
-	// 设置表格样式
-	t.SetStyles(GetDefaultTableStyles())
-	m.Table = t
 
-	// 计算可见列数并更新视图
-	m.CalculateMaxColumns()
-	m.UpdateVisibleColumns()
+	m.appendRows(data.Rows)
+	m.finishSource()
 
-	// 运行程序
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err = p.Run()
-	return err
+	return m.configure(cfg)
 }
 
 // 其他方法（如Init、Update、View）可以根据需要添加
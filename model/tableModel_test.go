@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// wideData 列数超过一屏的数据
+func wideData(cols int) TableData {
+	data := TableData{Rows: make([][]string, 3)}
+	for c := 0; c < cols; c++ {
+		data.Headers = append(data.Headers, fmt.Sprintf("列%02d", c))
+	}
+	for r := range data.Rows {
+		for c := 0; c < cols; c++ {
+			data.Rows[r] = append(data.Rows[r], fmt.Sprintf("%d-%d-%s", r, c, strings.Repeat("x", 20)))
+		}
+	}
+	return data
+}
+
+// TestColumnCursor 列光标独立于横向滚动，只有离开可见窗口时才滚动
+func TestColumnCursor(t *testing.T) {
+	const cols = 12
+	right := func(n int) []string { return strings.Split(strings.Repeat("right ", n), " ")[:n] }
+	cases := []struct {
+		name   string
+		keys   []string
+		col    int
+		offset int // -1 表示只检查光标列可见
+	}{
+		{"初始", nil, 0, 0},
+		{"窗口内移动不滚动", right(2), 2, 0},
+		{"移出窗口时滚动", right(cols - 1), cols - 1, -1},
+		{"最左列不能再左移", []string{"left"}, 0, 0},
+		{"末列", []string{"shift+right"}, cols - 1, -1},
+		{"末列后左移不滚动", []string{"shift+right", "left"}, cols - 2, -1},
+		{"首列", []string{"shift+right", "shift+left"}, 0, 0},
+	}
+	for _, c := range cases {
+		m := press(t, newTestModel(t, wideData(cols)), c.keys...)
+		if m.ColCursor != c.col {
+			t.Errorf("%s: 光标在第 %d 列，期望 %d", c.name, m.ColCursor, c.col)
+		}
+		if c.offset >= 0 && m.ScrollOffset != c.offset {
+			t.Errorf("%s: 滚动到第 %d 列，期望 %d", c.name, m.ScrollOffset, c.offset)
+		}
+		if m.ColCursor < m.ScrollOffset || m.ColCursor >= m.ScrollOffset+m.MaxColumns {
+			t.Errorf("%s: 光标列 %d 不在可见窗口 [%d, %d) 中", c.name, m.ColCursor, m.ScrollOffset, m.ScrollOffset+m.MaxColumns)
+		}
+		if want := m.AllRows[0][m.ColCursor]; m.SelectedCell() != want {
+			t.Errorf("%s: 当前单元格为 %q，期望 %q", c.name, m.SelectedCell(), want)
+		}
+	}
+}
+
+// TestColumnCursorKeepsOffset 窗口内左右移动时滚动位置保持不变
+func TestColumnCursorKeepsOffset(t *testing.T) {
+	m := press(t, newTestModel(t, wideData(12)), "shift+right")
+	offset := m.ScrollOffset
+	if offset == 0 {
+		t.Fatalf("%d 列可以全部显示，无法测试滚动", len(m.TableColumns))
+	}
+	for i := 0; i < m.MaxColumns-1; i++ {
+		m = press(t, m, "left")
+		if m.ScrollOffset != offset {
+			t.Fatalf("左移 %d 次后滚动到第 %d 列，期望保持 %d", i+1, m.ScrollOffset, offset)
+		}
+	}
+	m = press(t, m, "left")
+	if m.ScrollOffset != offset-1 {
+		t.Errorf("移出窗口后滚动到第 %d 列，期望 %d", m.ScrollOffset, offset-1)
+	}
+}
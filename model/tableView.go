@@ -0,0 +1,149 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 光标列高亮样式
+var (
+	activeHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("231")).
+				Background(lipgloss.Color("99"))
+
+	activeCellStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("16")).
+			Background(lipgloss.Color("212")).
+			Bold(true)
+)
+
+// visibleColumnRange 返回当前可见列的起止索引（左闭右开）
+func (m TableModel) visibleColumnRange() (int, int) {
+	start := m.ScrollOffset
+	if start < 0 {
+		start = 0
+	}
+	end := start + m.MaxColumns
+	if end > len(m.TableColumns) {
+		end = len(m.TableColumns)
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// bodyHeight 返回表格主体（不含表头）可显示的行数
+func (m TableModel) bodyHeight() int {
+	h := m.Height - lipgloss.Height(m.Theme.Table.Header.Render(""))
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// overlayStyle 用 top 的颜色等设置覆盖 base，保留 base 的内边距以免单元格错位
+func overlayStyle(base, top lipgloss.Style) lipgloss.Style {
+	return top.Inherit(base).Padding(base.GetPadding())
+}
+
+// fitCell 将内容截断并按对齐方式填充到指定宽度
+func fitCell(value string, width int, align lipgloss.Position) string {
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		Inline(true).
+		Align(align).
+		Render(TruncateWidth(value, width))
+}
+
+// headerTitle 返回带排序标记的列标题，标题过长时优先保留排序标记
+func (m TableModel) headerTitle(col int) string {
+	title := m.TableColumns[col].Title
+	indicator := m.sortIndicator(col)
+	if indicator == "" {
+		return title
+	}
+	width := m.TableColumns[col].Width - DisplayWidth(indicator) - 1
+	return TruncateWidth(title, width) + " " + indicator
+}
+
+// renderHeader 渲染可见范围内的表头，光标所在列单独高亮
+func (m TableModel) renderHeader(start, end int) string {
+	headers := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		col := m.TableColumns[i]
+		style := m.Theme.Table.Header
+		if i == m.ColCursor {
+			style = style.
+				Foreground(m.Theme.ActiveHeader.GetForeground()).
+				Background(m.Theme.ActiveHeader.GetBackground())
+		}
+		headers = append(headers, style.Render(fitCell(m.headerTitle(i), col.Width, lipgloss.Left)))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, headers...)
+}
+
+// renderTable 渲染表头和可见行，光标所在列的表头和单元格单独高亮
+func (m TableModel) renderTable() string {
+	styles := m.Theme.Table
+	start, end := m.visibleColumnRange()
+	lines := []string{m.renderHeader(start, end)}
+
+	cursor := m.Cursor.Index()
+	height := m.bodyHeight()
+	for r := m.RowOffset; r < len(m.OriginalRows) && r < m.RowOffset+height; r++ {
+		lines = append(lines, m.renderRow(r, start, end, r == cursor, styles))
+	}
+	// 已显示到已加载数据的末尾且正在读取下一页
+	if m.Loading && len(lines) < height+1 {
+		lines = append(lines, m.Theme.Info.Render("加载更多…"))
+	}
+	// 行数不足时补齐空行，保持布局稳定
+	for len(lines) < height+1 {
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderRow 渲染一行中可见范围内的单元格，搜索命中的文字单独高亮
+func (m TableModel) renderRow(r, start, end int, selected bool, styles table.Styles) string {
+	row := m.OriginalRows[r]
+	needle := []rune(strings.ToLower(m.SearchText))
+	picked := m.isRowSelected(r)
+	cells := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		value := cellAt(row, i)
+
+		style := styles.Cell
+		if selected {
+			style = style.Inherit(styles.Selected)
+			if i == m.ColCursor {
+				style = styles.Cell.Inherit(m.Theme.ActiveCell)
+			}
+		} else if picked {
+			style = style.Inherit(m.Theme.PickedRow)
+		}
+		if m.isChangedCell(r, i) {
+			style = overlayStyle(style, m.Theme.Changed)
+		}
+		if m.diff != nil {
+			style = m.diffCellStyle(style, r, i)
+		}
+		spec := m.columnSpec(i)
+		text := spec.FormatCell(value)
+		if spans := matchSpans(text, needle); len(spans) > 0 {
+			highlight := m.Theme.SearchMatch
+			if m.isCurrentMatch(r, i) {
+				highlight = m.Theme.CurrentMatch
+			}
+			cells = append(cells, renderHighlighted(text, m.TableColumns[i].Width, spec.Position(), style, highlight, spans))
+			continue
+		}
+		cells = append(cells, style.Render(fitCell(text, m.TableColumns[i].Width, spec.Position())))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
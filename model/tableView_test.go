@@ -0,0 +1,152 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 基准测试使用的数据规模，滚动和渲染的耗时应与行数无关
+var benchmarkSizes = []int{1000, 500000}
+
+// benchmarkModel 构建 n 行的表格并等待显示行计算完成
+func benchmarkModel(tb testing.TB, n int) TableModel {
+	tb.Helper()
+	data := TableData{
+		Title:   "benchmark",
+		Headers: []string{"ID", "名称", "数量", "城市", "备注"},
+		Rows:    make([][]string, n),
+	}
+	for i := range data.Rows {
+		data.Rows[i] = []string{strconv.Itoa(i), fmt.Sprintf("名称-%d", i%997), strconv.Itoa(i % 1000),
+			[]string{"北京", "上海", "深圳", "杭州"}[i%4], "备注"}
+	}
+	m := newTestModel(tb, data)
+	if m.RowCount != n || len(m.OriginalRows) != n {
+		tb.Fatalf("显示 %d 行，期望 %d 行", len(m.OriginalRows), n)
+	}
+	return m
+}
+
+// newTestModel 按 120x40 的终端尺寸构建表格，显示行在后台计算时同步等待结果
+func newTestModel(tb testing.TB, data TableData, opts ...Option) TableModel {
+	tb.Helper()
+	m, err := NewTableModelFromData(data, opts...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = tm.(TableModel)
+	finishViewJob(tb, &m)
+	return m
+}
+
+// finishViewJob 同步执行正在等待的显示行计算，避免测试依赖后台任务的完成时间
+func finishViewJob(tb testing.TB, m *TableModel) {
+	tb.Helper()
+	m.startViewJob()
+	if m.viewJob == nil {
+		return
+	}
+	result, err := computeView(context.Background(), m.viewInput(true), nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	m.handleViewComputed(viewComputedMsg{gen: m.viewJob.gen, result: result})
+}
+
+// namedKeys 测试中按名称发送的特殊按键，其余文本作为输入的字符发送
+var namedKeys = map[string]tea.KeyType{
+	"enter":       tea.KeyEnter,
+	"esc":         tea.KeyEsc,
+	"up":          tea.KeyUp,
+	"down":        tea.KeyDown,
+	"left":        tea.KeyLeft,
+	"right":       tea.KeyRight,
+	"shift+left":  tea.KeyShiftLeft,
+	"shift+right": tea.KeyShiftRight,
+	"tab":         tea.KeyTab,
+	"space":       tea.KeySpace,
+	"backspace":   tea.KeyBackspace,
+	"ctrl+r":      tea.KeyCtrlR,
+}
+
+// keyMsg 返回名称对应的按键消息
+func keyMsg(k string) tea.KeyMsg {
+	if t, ok := namedKeys[k]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// press 依次通过 Update 发送按键，每次按键后同步完成显示行的计算
+func press(tb testing.TB, m TableModel, keys ...string) TableModel {
+	tb.Helper()
+	for _, k := range keys {
+		tm, _ := m.Update(keyMsg(k))
+		m = tm.(TableModel)
+		finishViewJob(tb, &m)
+	}
+	return m
+}
+
+// BenchmarkScroll 通过 Update 逐行移动光标，到达末行后反向移动
+func BenchmarkScroll(b *testing.B) {
+	down, up := tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyUp}
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("rows=%d", n), func(b *testing.B) {
+			var tm tea.Model = benchmarkModel(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				msg := down
+				if i/(n-1)%2 == 1 {
+					msg = up
+				}
+				tm, _ = tm.Update(msg)
+			}
+		})
+	}
+}
+
+// BenchmarkView 每次移动光标后渲染一次，模拟按住方向键滚动
+func BenchmarkView(b *testing.B) {
+	down, up := tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyUp}
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("rows=%d", n), func(b *testing.B) {
+			var tm tea.Model = benchmarkModel(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				msg := down
+				if i/(n-1)%2 == 1 {
+					msg = up
+				}
+				tm, _ = tm.Update(msg)
+				_ = tm.View()
+			}
+		})
+	}
+}
+
+// TestScrollCostIndependentOfRows 滚动一行的内存分配次数不随行数增长
+func TestScrollCostIndependentOfRows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("需要构建 50 万行的表格")
+	}
+	allocs := make([]float64, len(benchmarkSizes))
+	for i, n := range benchmarkSizes {
+		var tm tea.Model = benchmarkModel(t, n)
+		allocs[i] = testing.AllocsPerRun(100, func() {
+			tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyDown})
+			_ = tm.View()
+		})
+	}
+	small, large := allocs[0], allocs[len(allocs)-1]
+	if large > small*1.1+2 {
+		t.Errorf("%d 行时每次滚动分配 %.0f 次，%d 行时 %.0f 次", benchmarkSizes[0], small, benchmarkSizes[len(benchmarkSizes)-1], large)
+	}
+}
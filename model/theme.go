@@ -0,0 +1,80 @@
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme 界面配色
+type Theme struct {
+	Table            table.Styles   // 表头、单元格和光标行
+	Base             lipgloss.Style // 表格外框
+	Title            lipgloss.Style // 标题行
+	Info             lipgloss.Style // 导航信息行
+	Help             lipgloss.Style // 帮助信息
+	Status           lipgloss.Style // 状态消息
+	Error            lipgloss.Style // 筛选表达式错误
+	ActiveHeader     lipgloss.Style // 光标列表头
+	ActiveCell       lipgloss.Style // 光标单元格
+	PickedRow        lipgloss.Style // 已选中行
+	SearchMatch      lipgloss.Style // 搜索命中
+	CurrentMatch     lipgloss.Style // 当前搜索命中
+	SearchBar        lipgloss.Style // 搜索栏标签
+	FilterBar        lipgloss.Style // 筛选栏标签
+	FilterInput      lipgloss.Style // 筛选栏输入框
+	FilterChip       lipgloss.Style // 筛选条件标签
+	ActiveFilterChip lipgloss.Style // 选择模式下的当前筛选条件标签
+	Changed          lipgloss.Style // 刷新后发生变化的单元格
+	DiffAdded        lipgloss.Style // 差异模式下新增的行
+	DiffRemoved      lipgloss.Style // 差异模式下删除的行
+	DiffChanged      lipgloss.Style // 差异模式下修改过的单元格
+	FieldName        lipgloss.Style // 记录详情和列统计中的列名
+	Syntax           SyntaxStyles   // 单元格查看器的语法着色
+	Pane             lipgloss.Style // 预览栏边框，宽高按它的边框和内边距扣除
+	FrequencyBar     lipgloss.Style // 值分布中的条形图
+	GroupHeader      lipgloss.Style // 分组标题行
+}
+
+// DefaultTheme 返回默认配色
+func DefaultTheme() Theme {
+	return Theme{
+		Table:            GetDefaultTableStyles(),
+		Base:             baseStyle,
+		Title:            titleStyle,
+		Info:             infoStyle,
+		Help:             helpStyle,
+		Status:           statusStyle,
+		Error:            filterErrorStyle,
+		ActiveHeader:     activeHeaderStyle,
+		ActiveCell:       activeCellStyle,
+		PickedRow:        pickedRowStyle,
+		SearchMatch:      searchMatchStyle,
+		CurrentMatch:     currentSearchMatchStyle,
+		SearchBar:        searchBarStyle,
+		FilterBar:        filterBarStyle,
+		FilterInput:      filterInputStyle,
+		FilterChip:       filterChipStyle,
+		ActiveFilterChip: activeFilterChipStyle,
+		Changed:          changedCellStyle,
+		DiffAdded:        diffAddedStyle,
+		DiffRemoved:      diffRemovedStyle,
+		DiffChanged:      diffChangedStyle,
+		FieldName:        detailNameStyle,
+		Syntax:           defaultSyntaxStyles,
+		Pane:             paneStyle,
+		FrequencyBar:     freqBarStyle,
+		GroupHeader:      groupHeaderStyle,
+	}
+}
+
+// SyntaxStyles 单元格查看器中 JSON、XML、YAML 的语法着色
+type SyntaxStyles struct {
+	Key     lipgloss.Style // 对象的键
+	String  lipgloss.Style // 字符串
+	Number  lipgloss.Style // 数字
+	Literal lipgloss.Style // true、false、null 等
+	Punct   lipgloss.Style // 括号、冒号、逗号和折叠标记
+	Tag     lipgloss.Style // XML 标签名
+	Attr    lipgloss.Style // XML 属性名
+	Comment lipgloss.Style // 注释和折叠后的省略号
+}
@@ -0,0 +1,392 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 值分布中条形图的样式
+var freqBarStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("63"))
+
+// 值分布中空值的显示文本
+const blankValueText = "(空)"
+
+// freqPanel 值分布面板的状态，列出当前列每个不同的值及其行数
+type freqPanel struct {
+	column   int
+	values   []valueCount    // 不同的值，nil 表示正在计算
+	total    int             // 参与统计的行数
+	byValue  bool            // 按值排序，默认按行数从多到少
+	cursor   int             // 光标所在的值
+	offset   int             // 第一个显示的值
+	selected map[string]bool // 选中的值
+	gen      int
+	cancel   context.CancelFunc
+}
+
+// freqComputedMsg 后台统计值分布完成
+type freqComputedMsg struct {
+	gen    int
+	values []valueCount
+	err    error
+}
+
+// OpenFrequency 打开光标所在列的值分布面板，数据量大时在后台计算
+func (m *TableModel) OpenFrequency() tea.Cmd {
+	if len(m.TableColumns) == 0 {
+		return nil
+	}
+	if m.viewJob != nil || m.viewPending {
+		m.StatusMsg = "正在排序/筛选，完成后再查看值分布"
+		return nil
+	}
+	// 没有数据时显示行中只有“无数据”占位行，不参与统计
+	if m.RowCount == 0 {
+		m.StatusMsg = "没有数据，无法查看值分布"
+		return nil
+	}
+	m.CloseFrequency()
+	m.CloseStats()
+	m.ShowDiffDetail = false
+
+	col := m.ColCursor
+	rows := m.OriginalRows
+	m.statsGen++
+	panel := &freqPanel{column: col, total: len(rows), selected: make(map[string]bool), gen: m.statsGen}
+	m.freq = panel
+	if len(rows) < asyncRowThreshold {
+		values, _ := countValues(context.Background(), rows, col)
+		m.setFrequency(values)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	panel.cancel = cancel
+	gen := panel.gen
+	work := func() tea.Msg {
+		values, err := countValues(ctx, rows, col)
+		return freqComputedMsg{gen: gen, values: values, err: err}
+	}
+	return tea.Batch(work, m.Spinner.Tick)
+}
+
+// CloseFrequency 关闭值分布面板，取消仍在执行的计算
+func (m *TableModel) CloseFrequency() {
+	if m.freq != nil && m.freq.cancel != nil {
+		m.freq.cancel()
+	}
+	m.freq = nil
+}
+
+// FrequencyOpen 是否正在显示值分布面板
+func (m TableModel) FrequencyOpen() bool {
+	return m.freq != nil
+}
+
+// handleFreqComputed 应用后台统计结果，丢弃已被取代的结果
+func (m *TableModel) handleFreqComputed(msg freqComputedMsg) {
+	if m.freq == nil || msg.gen != m.freq.gen || msg.err != nil {
+		return
+	}
+	m.freq.cancel()
+	m.freq.cancel = nil
+	m.setFrequency(msg.values)
+}
+
+// setFrequency 保存统计结果并按当前方式排序，光标停在第一个值上
+func (m *TableModel) setFrequency(values []valueCount) {
+	if values == nil {
+		values = []valueCount{}
+	}
+	m.freq.values = values
+	m.sortFrequency()
+	m.freq.cursor = 0
+	m.clampFrequency()
+}
+
+// sortFrequency 按行数或按值排序，光标停留在原来的值上
+func (m *TableModel) sortFrequency() {
+	p := m.freq
+	current := ""
+	if p.cursor < len(p.values) {
+		current = p.values[p.cursor].value
+	}
+	spec := m.columnSpec(p.column)
+	sort.SliceStable(p.values, func(i, j int) bool {
+		a, b := p.values[i], p.values[j]
+		if p.byValue {
+			return spec.Compare(spec.Parse(a.value), spec.Parse(b.value)) < 0
+		}
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		return a.value < b.value
+	})
+	for i, vc := range p.values {
+		if vc.value == current {
+			p.cursor = i
+			break
+		}
+	}
+	m.clampFrequency()
+}
+
+// countValues 统计 rows 中第 col 列每个值的行数，空白的值计为空值
+func countValues(ctx context.Context, rows []table.Row, col int) ([]valueCount, error) {
+	counts := make(map[string]int)
+	for i, row := range rows {
+		if i%viewCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		cell := cellAt(row, col)
+		if strings.TrimSpace(cell) == "" {
+			cell = ""
+		}
+		counts[cell]++
+	}
+	values := make([]valueCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, valueCount{value: value, count: count})
+	}
+	return values, nil
+}
+
+// updateFrequency 处理值分布面板中的按键：移动、选择、切换排序和筛选
+func (m *TableModel) updateFrequency(msg tea.KeyMsg) tea.Cmd {
+	p := m.freq
+	if key.Matches(msg, m.Keys.Frequency) || key.Matches(msg, m.Keys.Cancel) {
+		m.CloseFrequency()
+		return nil
+	}
+	// 计算完成之前只能关闭
+	if p.values == nil {
+		return nil
+	}
+
+	page := max(m.frequencyHeight()-1, 1)
+	switch {
+	case key.Matches(msg, m.Keys.Up):
+		p.cursor--
+	case key.Matches(msg, m.Keys.Down):
+		p.cursor++
+	case key.Matches(msg, m.Keys.PageUp):
+		p.cursor -= page
+	case key.Matches(msg, m.Keys.PageDown):
+		p.cursor += page
+	case key.Matches(msg, m.Keys.Select):
+		if p.cursor < len(p.values) {
+			value := p.values[p.cursor].value
+			if p.selected[value] {
+				delete(p.selected, value)
+			} else {
+				p.selected[value] = true
+			}
+			p.cursor++
+		}
+	case key.Matches(msg, m.Keys.Sort):
+		p.byValue = !p.byValue
+		m.sortFrequency()
+	case key.Matches(msg, m.Keys.Detail), key.Matches(msg, m.Keys.Confirm):
+		m.applyFrequencyFilter()
+		return nil
+	}
+	m.clampFrequency()
+	return nil
+}
+
+// clampFrequency 将光标和滚动位置限制在值的范围内
+func (m *TableModel) clampFrequency() {
+	p := m.freq
+	p.cursor = max(min(p.cursor, len(p.values)-1), 0)
+	height := m.frequencyHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+height {
+		p.offset = p.cursor - height + 1
+	}
+	p.offset = max(min(p.offset, len(p.values)-height), 0)
+}
+
+// frequencyHeight 返回值分布中可显示的值的个数
+func (m TableModel) frequencyHeight() int {
+	// 标题和表头各占一行
+	return max(m.bodyHeight()-1, 1)
+}
+
+// applyFrequencyFilter 按选中的值（未选中时为光标所在的值）为表格添加筛选条件并关闭面板
+func (m *TableModel) applyFrequencyFilter() {
+	p := m.freq
+	var values []string
+	for _, vc := range p.values {
+		if p.selected[vc.value] {
+			values = append(values, vc.value)
+		}
+	}
+	if len(values) == 0 && p.cursor < len(p.values) {
+		values = append(values, p.values[p.cursor].value)
+	}
+	m.CloseFrequency()
+	if len(values) == 0 || !m.Keys.Filter.Enabled() {
+		return
+	}
+
+	expr := valuesFilter(p.column, m.columnSpec(p.column), values)
+	m.Filters = append(m.Filters, expr)
+	m.ApplyFilter()
+	m.StatusMsg = fmt.Sprintf("已添加筛选条件 [%s]", expr)
+}
+
+// valuesFilter 生成匹配任一指定值的筛选条件，与统计时一样按原文匹配，空白的值都计为空值
+func valuesFilter(col int, spec ColumnSpec, values []string) FilterExpr {
+	expr := textInExpr{col: col, spec: spec, values: values, set: make(map[string]bool, len(values))}
+	for _, v := range values {
+		expr.set[v] = true
+	}
+	return expr
+}
+
+// textInExpr 按原文匹配任一指定值，值分布面板统计的行数与筛选结果一致
+// 字符串列的文本形式与 = / in 相同；其他类型的列按值比较时 "001" 与 "1" 相等，
+// 因此写作锚定的正则表达式，解析回来后仍按原文匹配。空值写作 "" 或 \s*
+type textInExpr struct {
+	col    int
+	spec   ColumnSpec
+	values []string
+	set    map[string]bool
+}
+
+func (e textInExpr) Match(row table.Row) bool {
+	cell := cellAt(row, e.col)
+	if strings.TrimSpace(cell) == "" {
+		cell = ""
+	}
+	return e.set[cell]
+}
+
+func (e textInExpr) String() string {
+	name := quoteColumn(e.spec.Name)
+	if e.spec.Type != ColumnString {
+		parts := make([]string, len(e.values))
+		for i, v := range e.values {
+			if v == "" {
+				parts[i] = `\s*`
+			} else {
+				parts[i] = regexp.QuoteMeta(v)
+			}
+		}
+		pattern := strings.Join(parts, "|")
+		if len(parts) > 1 {
+			pattern = "(?:" + pattern + ")"
+		}
+		return fmt.Sprintf("%s ~ %s", name, quoteText("^"+pattern+"$", '"'))
+	}
+	if len(e.values) == 1 {
+		return fmt.Sprintf("%s = %s", name, quoteLiteral(e.values[0]))
+	}
+	parts := make([]string, len(e.values))
+	for i, v := range e.values {
+		parts[i] = quoteLiteral(v)
+	}
+	return fmt.Sprintf("%s in (%s)", name, strings.Join(parts, ", "))
+}
+
+// frequencyView 显示值分布面板，高度与表格相同
+func (m TableModel) frequencyView() string {
+	p := m.freq
+	height := m.bodyHeight() + 1
+	title := fmt.Sprintf("值分布: %s（%s 选择，%s 筛选，%s 切换排序，%s 关闭）", m.columnName(p.column),
+		m.Keys.Select.Help().Key, m.Keys.Detail.Help().Key, m.Keys.Sort.Help().Key,
+		joinKeyPair(m.Keys.Frequency.Help().Key, m.Keys.Cancel.Help().Key))
+	lines := []string{m.Theme.Title.Render(title)}
+
+	if p.values == nil {
+		lines = append(lines, m.Theme.Info.Render(fmt.Sprintf("%s统计 %d 行中...", m.Spinner.View(), p.total)))
+	} else {
+		lines = append(lines, m.frequencyLines()...)
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// frequencyLines 返回表头和可见的值，每个值显示行数、占比和条形图
+func (m TableModel) frequencyLines() []string {
+	p := m.freq
+	spec := m.columnSpec(p.column)
+	display := func(value string) string {
+		if value == "" {
+			return blankValueText
+		}
+		return spec.FormatCell(value)
+	}
+
+	maxCount := 0
+	valueWidth, countWidth := DisplayWidth("值"), DisplayWidth("行数")
+	for _, vc := range p.values {
+		maxCount = max(maxCount, vc.count)
+		valueWidth = max(valueWidth, DisplayWidth(display(vc.value)))
+		countWidth = max(countWidth, DisplayWidth(fmt.Sprint(vc.count)))
+	}
+	valueWidth = min(valueWidth, max(m.Width/3, 8))
+	const percentWidth = 6
+	// 行首的光标和选中标记占 4 列，各部分之间空 2 列
+	barWidth := max(m.Width-valueWidth-countWidth-percentWidth-4-6, 0)
+
+	order := "按行数"
+	if p.byValue {
+		order = "按值"
+	}
+	header := fmt.Sprintf("    %s  %s  %s  %d 个不同的值，%s排序",
+		fitCell("值", valueWidth, lipgloss.Left), fitCell("行数", countWidth, lipgloss.Right),
+		fitCell("占比", percentWidth, lipgloss.Right), len(p.values), order)
+	if len(p.selected) > 0 {
+		header += fmt.Sprintf("，已选 %d 个", len(p.selected))
+	}
+	lines := []string{m.Theme.Table.Header.Render(TruncateWidth(header, m.Width))}
+
+	for i := p.offset; i < len(p.values) && i < p.offset+m.frequencyHeight(); i++ {
+		vc := p.values[i]
+		cursor, mark := "  ", "  "
+		if i == p.cursor {
+			cursor = m.Theme.ActiveHeader.Render("›") + " "
+		}
+		if p.selected[vc.value] {
+			mark = "✓ "
+		}
+		percent := 0.0
+		if p.total > 0 {
+			percent = float64(vc.count) * 100 / float64(p.total)
+		}
+		bar := ""
+		if maxCount > 0 {
+			bar = strings.Repeat("█", max(barWidth*vc.count/maxCount, 1))
+		}
+		text := fitCell(display(vc.value), valueWidth, lipgloss.Left) + "  " +
+			fitCell(fmt.Sprint(vc.count), countWidth, lipgloss.Right) + "  " +
+			fitCell(fmt.Sprintf("%.1f%%", percent), percentWidth, lipgloss.Right)
+		switch {
+		case i == p.cursor:
+			text = m.Theme.Table.Selected.Render(text)
+		case p.selected[vc.value]:
+			text = m.Theme.PickedRow.Render(text)
+		}
+		lines = append(lines, cursor+mark+text+"  "+m.Theme.FrequencyBar.Render(bar))
+	}
+	return lines
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestCountValues 按原文统计每个值的行数，空白的值都计为空值
+func TestCountValues(t *testing.T) {
+	rows := []table.Row{{"北京"}, {"上海"}, {"北京"}, {""}, {"  "}, {"001"}, {"1"}, {}}
+	got, err := countValues(context.Background(), rows, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].value < got[j].value })
+	want := []valueCount{{"", 3}, {"001", 1}, {"1", 1}, {"上海", 1}, {"北京", 2}}
+	if len(got) != len(want) {
+		t.Fatalf("得到 %v，期望 %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("第 %d 项为 %v，期望 %v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestOpenFrequency 打开值分布面板，没有数据时只提示而不统计占位行
+func TestOpenFrequency(t *testing.T) {
+	cases := []struct {
+		name   string
+		rows   [][]string
+		open   bool
+		values int
+	}{
+		{"有数据", [][]string{{"1", "北京"}, {"2", "上海"}, {"3", "北京"}}, true, 2},
+		{"没有数据", nil, false, 0},
+	}
+	for _, c := range cases {
+		m := newTestModel(t, TableData{Headers: []string{"ID", "城市"}, Rows: c.rows})
+		m.MoveColumnCursor(1)
+		m.OpenFrequency()
+		if (m.freq != nil) != c.open {
+			t.Errorf("%s: 面板打开为 %v，期望 %v", c.name, m.freq != nil, c.open)
+			continue
+		}
+		if !c.open {
+			if m.StatusMsg == "" {
+				t.Errorf("%s: 没有提示信息", c.name)
+			}
+			continue
+		}
+		if len(m.freq.values) != c.values {
+			t.Errorf("%s: 统计得到 %d 个值，期望 %d", c.name, len(m.freq.values), c.values)
+		}
+	}
+}
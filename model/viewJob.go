@@ -0,0 +1,380 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// asyncRowThreshold 数据行达到该数量时排序和筛选在后台执行
+const asyncRowThreshold = 50000
+
+// 每处理多少行检查一次取消并更新进度
+const viewCheckInterval = 4096
+
+// 后台计算所处的阶段
+const (
+	phaseStart int32 = iota
+	phaseFilter
+	phaseParse
+	phaseSort
+)
+
+// viewInput 计算显示行所需的数据快照，计算过程中只读
+type viewInput struct {
+	rows    []table.Row
+	filters []FilterExpr // 本地筛选条件，为空表示不筛选
+	keys    []SortKey    // 本地排序键，为空表示保持原始顺序
+	specs   []ColumnSpec
+	base    []int // 沿用的筛选结果，非 nil 时跳过筛选
+
+	appliedFilters  []FilterExpr // 计算对应的筛选条件
+	appliedSort     []SortKey    // 计算对应的排序栈
+	appliedDiffOnly bool         // 计算时是否只保留有变更的行
+}
+
+// viewResult 计算得到的显示行
+type viewResult struct {
+	filteredIndex   []int // 满足筛选条件的行在 AllRows 中的索引，未筛选时为 nil
+	order           []int // 显示顺序，元素为 AllRows 中的索引
+	appliedFilters  []FilterExpr
+	appliedSort     []SortKey
+	appliedDiffOnly bool
+	appliedRows     int // 计算时 AllRows 的行数
+}
+
+// viewProgress 后台计算的进度，由工作协程写入、界面读取
+type viewProgress struct {
+	phase atomic.Int32
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+// viewJob 正在后台执行的排序/筛选
+type viewJob struct {
+	gen      int
+	cancel   context.CancelFunc
+	progress *viewProgress
+}
+
+// viewComputedMsg 后台排序/筛选完成
+type viewComputedMsg struct {
+	gen    int
+	result viewResult
+	err    error
+}
+
+// refreshViewMsg 请求开始尚未执行的后台排序/筛选
+type refreshViewMsg struct{}
+
+// refreshRows 重新计算显示行，refilter 为 false 时沿用已有的筛选结果只重新排序
+// 数据量较小时立即完成，否则标记为待处理，由 Update 在后台执行
+func (m *TableModel) refreshRows(refilter bool) {
+	if len(m.AllRows) >= asyncRowThreshold {
+		m.viewPending = true
+		m.viewRefilter = m.viewRefilter || refilter
+		m.viewUrgent = m.viewUrgent || !m.appending
+		return
+	}
+	// 同步结果取代仍在后台执行的任务
+	if m.viewJob != nil {
+		m.viewJob.cancel()
+		m.viewJob = nil
+	}
+	result, _ := computeView(context.Background(), m.viewInput(refilter), nil)
+	m.applyView(result)
+}
+
+// viewInput 为当前排序和筛选条件生成数据快照
+func (m TableModel) viewInput(refilter bool) viewInput {
+	in := viewInput{
+		rows:            m.AllRows,
+		specs:           m.columnSpecs(),
+		appliedFilters:  append([]FilterExpr(nil), m.Filters...),
+		appliedSort:     append([]SortKey(nil), m.SortKeys...),
+		appliedDiffOnly: m.ShowDiffOnly,
+	}
+	// 下推给数据源的条件不在本地计算
+	if !m.filterPushedDown() {
+		in.filters = in.appliedFilters
+	}
+	// 只显示有变更的行不属于筛选条件，总在本地计算
+	if m.ShowDiffOnly {
+		in.filters = append(in.filters[:len(in.filters):len(in.filters)], diffOnlyFilter{})
+	}
+	if !m.sortPushedDown() {
+		in.keys = in.appliedSort
+	}
+	if !refilter && len(in.filters) > 0 && len(m.filteredIndex) > 0 {
+		in.base = m.filteredIndex
+	}
+	return in
+}
+
+// computeView 按筛选条件和排序栈计算显示行，progress 可为 nil
+func computeView(ctx context.Context, in viewInput, progress *viewProgress) (viewResult, error) {
+	if progress == nil {
+		progress = &viewProgress{}
+	}
+	result := viewResult{
+		appliedFilters:  in.appliedFilters,
+		appliedSort:     in.appliedSort,
+		appliedDiffOnly: in.appliedDiffOnly,
+		appliedRows:     len(in.rows),
+	}
+
+	// 筛选
+	var order []int
+	switch {
+	case in.base != nil:
+		order = append([]int(nil), in.base...)
+		result.filteredIndex = in.base
+	case len(in.filters) > 0:
+		progress.phase.Store(phaseFilter)
+		progress.total.Store(int64(len(in.rows)))
+		order = make([]int, 0)
+		for i, row := range in.rows {
+			if i%viewCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+				progress.done.Store(int64(i))
+			}
+			if matchAll(in.filters, row) {
+				order = append(order, i)
+			}
+		}
+		result.filteredIndex = append([]int(nil), order...)
+	default:
+		order = make([]int, len(in.rows))
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	if len(in.keys) > 0 {
+		// 每个排序键只解析一次，避免比较时重复解析字符串
+		progress.phase.Store(phaseParse)
+		progress.total.Store(int64(len(order)))
+		keys := in.keys
+		values := make([]Value, len(order)*len(keys))
+		for p, idx := range order {
+			if p%viewCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+				progress.done.Store(int64(p))
+			}
+			for k, key := range keys {
+				values[p*len(keys)+k] = columnSpecAt(in.specs, key.Column).Parse(cellAt(in.rows[idx], key.Column))
+			}
+		}
+
+		// 对位置排序，比较时按位置取预先解析的值
+		progress.phase.Store(phaseSort)
+		perm := make([]int, len(order))
+		for i := range perm {
+			perm[i] = i
+		}
+		compared := 0
+		canceled := false
+		sort.SliceStable(perm, func(i, j int) bool {
+			// 取消后让剩余的比较尽快结束
+			if canceled {
+				return false
+			}
+			if compared++; compared%viewCheckInterval == 0 && ctx.Err() != nil {
+				canceled = true
+				return false
+			}
+			a, b := perm[i]*len(keys), perm[j]*len(keys)
+			for k, key := range keys {
+				c := columnSpecAt(in.specs, key.Column).Compare(values[a+k], values[b+k])
+				if c == 0 {
+					continue
+				}
+				if key.Desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+		if canceled {
+			return result, ctx.Err()
+		}
+
+		sorted := make([]int, len(order))
+		for i, p := range perm {
+			sorted[i] = order[p]
+		}
+		order = sorted
+	}
+
+	result.order = order
+	return result, nil
+}
+
+// matchAll 判断行是否满足全部筛选条件
+func matchAll(filters []FilterExpr, row table.Row) bool {
+	for _, f := range filters {
+		if !f.Match(row) {
+			return false
+		}
+	}
+	return true
+}
+
+// columnSpecAt 返回列定义，越界时视为字符串列
+func columnSpecAt(specs []ColumnSpec, col int) ColumnSpec {
+	if col >= 0 && col < len(specs) {
+		return specs[col]
+	}
+	return ColumnSpec{}
+}
+
+// applyView 用计算结果一次性替换显示行，光标跟随原来所在的记录
+func (m *TableModel) applyView(result viewResult) {
+	// 结果中的索引都小于计算时的行数，数据在计算期间被替换为更少的行时丢弃结果并重新计算
+	if result.appliedRows > len(m.AllRows) {
+		m.viewPending = true
+		m.viewRefilter = true
+		return
+	}
+	if cursorRow, ok := m.cursorRowIndex(); ok && m.followRow < 0 {
+		m.followRow = cursorRow
+		m.followScreen = m.Cursor.Index() - m.RowOffset
+	}
+
+	m.filteredIndex = result.filteredIndex
+	m.FilteredRows = nil
+	if result.filteredIndex != nil {
+		m.FilteredRows = make([]table.Row, len(result.filteredIndex))
+		for i, idx := range result.filteredIndex {
+			m.FilteredRows[i] = m.AllRows[idx]
+		}
+	}
+
+	rows := make([]table.Row, len(result.order))
+	for i, idx := range result.order {
+		rows[i] = m.AllRows[idx]
+	}
+	m.OriginalRows = rows
+	m.RowIndex = result.order
+	m.appliedFilters = result.appliedFilters
+	m.appliedSort = result.appliedSort
+	m.appliedDiffOnly = result.appliedDiffOnly
+	m.appliedRows = result.appliedRows
+	m.rowsVersion++
+
+	m.placeFollowRow()
+	m.refreshSearch()
+	m.UpdateVisibleColumns()
+	m.rebuildGroups()
+}
+
+// placeFollowRow 让光标停留在 followRow 指定的行上，设置了 followScreen 时同时保持它在屏幕上的位置
+func (m *TableModel) placeFollowRow() {
+	if m.followRow < 0 {
+		return
+	}
+	for i, idx := range m.RowIndex {
+		if idx == m.followRow {
+			m.Cursor.SetLen(len(m.RowIndex))
+			m.Cursor.Set(i)
+			if m.followScreen >= 0 {
+				m.RowOffset = i - m.followScreen
+				m.scrollToCursor()
+			}
+			break
+		}
+	}
+	m.followRow = -1
+	m.followScreen = -1
+}
+
+// startViewJob 在后台执行待处理的排序/筛选，取代仍在执行的旧任务
+func (m *TableModel) startViewJob() tea.Cmd {
+	if !m.viewPending {
+		return nil
+	}
+	if m.viewJob != nil {
+		// 仅因追加数据而需要重新计算时，等当前任务完成后再执行
+		if !m.viewUrgent {
+			return nil
+		}
+		m.viewJob.cancel()
+	}
+	m.viewUrgent = false
+
+	in := m.viewInput(m.viewRefilter)
+	m.viewPending = false
+	m.viewRefilter = false
+	m.viewGen++
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &viewJob{gen: m.viewGen, cancel: cancel, progress: &viewProgress{}}
+	m.viewJob = job
+
+	work := func() tea.Msg {
+		result, err := computeView(ctx, in, job.progress)
+		return viewComputedMsg{gen: job.gen, result: result, err: err}
+	}
+	return tea.Batch(work, m.Spinner.Tick)
+}
+
+// handleViewComputed 应用后台计算结果，丢弃已被取代或取消的结果
+func (m *TableModel) handleViewComputed(msg viewComputedMsg) {
+	if m.viewJob == nil || msg.gen != m.viewJob.gen {
+		return
+	}
+	m.viewJob.cancel()
+	m.viewJob = nil
+	if msg.err != nil {
+		return
+	}
+	m.applyView(msg.result)
+}
+
+// CancelViewJob 取消后台排序/筛选，排序和筛选条件恢复为当前显示结果对应的状态
+func (m *TableModel) CancelViewJob() {
+	if m.viewJob == nil {
+		return
+	}
+	m.viewJob.cancel()
+	m.viewJob = nil
+	// 任务执行期间追加的数据仍需按原来的条件显示
+	m.viewPending = len(m.AllRows) != m.appliedRows
+	m.viewRefilter = m.viewPending
+	m.viewUrgent = false
+	m.Filters = append([]FilterExpr(nil), m.appliedFilters...)
+	m.SortKeys = append([]SortKey(nil), m.appliedSort...)
+	m.ShowDiffOnly = m.appliedDiffOnly
+	m.StatusMsg = "已取消，继续显示原来的结果"
+}
+
+// viewJobStatus 返回后台任务的进度描述，没有任务时返回空字符串
+func (m TableModel) viewJobStatus() string {
+	if m.viewJob == nil {
+		return ""
+	}
+	p := m.viewJob.progress
+	phase := "处理中"
+	switch p.phase.Load() {
+	case phaseFilter:
+		phase = "筛选中"
+	case phaseParse:
+		phase = "解析排序列"
+	case phaseSort:
+		return fmt.Sprintf("%s排序中 (esc 取消)", m.Spinner.View())
+	}
+	percent := 0.0
+	if total := p.total.Load(); total > 0 {
+		percent = float64(p.done.Load()) * 100 / float64(total)
+	}
+	return fmt.Sprintf("%s%s %.0f%% (esc 取消)", m.Spinner.View(), phase, percent)
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TestComputeView 先筛选再按排序栈稳定排序，结果中的索引指向原始行
+func TestComputeView(t *testing.T) {
+	data := TableData{
+		Headers: []string{"名称", "数量"},
+		Rows:    [][]string{{"b", "10"}, {"a", "9"}, {"b", "2"}, {"a", "10"}, {"c", ""}},
+	}
+	specs := resolveColumnSpecs(data)
+	rows := make([]table.Row, len(data.Rows))
+	for i, row := range data.Rows {
+		rows[i] = row
+	}
+	cases := []struct {
+		name         string
+		filters      []string
+		keys         []SortKey
+		base         []int
+		wantOrder    []int
+		wantFiltered []int
+	}{
+		{"原始顺序", nil, nil, nil, []int{0, 1, 2, 3, 4}, nil},
+		{"按数值排序，空值在前", nil, []SortKey{{Column: 1}}, nil, []int{4, 2, 1, 0, 3}, nil},
+		{"倒序保持稳定", nil, []SortKey{{Column: 0, Desc: true}}, nil, []int{4, 0, 2, 1, 3}, nil},
+		{"多列排序", nil, []SortKey{{Column: 0}, {Column: 1, Desc: true}}, nil, []int{3, 1, 0, 2, 4}, nil},
+		{"筛选后排序", []string{"数量 >= 9"}, []SortKey{{Column: 0}}, nil, []int{1, 3, 0}, []int{0, 1, 3}},
+		{"沿用筛选结果", []string{"名称 = c"}, []SortKey{{Column: 1}}, []int{0, 2}, []int{2, 0}, []int{0, 2}},
+	}
+	for _, c := range cases {
+		in := viewInput{rows: rows, keys: c.keys, specs: specs, base: c.base}
+		for _, text := range c.filters {
+			in.filters = append(in.filters, mustParseFilter(t, text, data, 0))
+		}
+		result, err := computeView(context.Background(), in, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(result.order, c.wantOrder) || !slices.Equal(result.filteredIndex, c.wantFiltered) {
+			t.Errorf("%s: 显示 %v、筛选 %v，期望 %v、%v", c.name, result.order, result.filteredIndex, c.wantOrder, c.wantFiltered)
+		}
+	}
+}
+
+// largeModel 返回行数达到后台计算阈值的表格
+func largeModel(t *testing.T) TableModel {
+	t.Helper()
+	rows := make([][]string, asyncRowThreshold)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+	return newTestModel(t, TableData{Headers: []string{"ID"}, Rows: rows})
+}
+
+// TestCancelViewJob 取消后台任务后继续显示原来的结果，排序、筛选和只看变更恢复为对应的状态
+func TestCancelViewJob(t *testing.T) {
+	cases := []struct {
+		name   string
+		change func(m *TableModel)
+	}{
+		{"排序", func(m *TableModel) { m.ToggleSort(0, false) }},
+		{"筛选", func(m *TableModel) {
+			if err := m.AddFilter("ID > 10"); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"只看变更", func(m *TableModel) { m.ToggleDiffOnly() }},
+	}
+	for _, c := range cases {
+		m := largeModel(t)
+		if err := m.AddFilter("ID < 100"); err != nil {
+			t.Fatal(err)
+		}
+		m.ToggleSort(0, false)
+		m.ToggleSort(0, false)
+		finishViewJob(t, &m)
+		filters, keys, shown := filtersSignature(m.Filters), slices.Clone(m.SortKeys), m.OriginalRows[0][0]
+
+		c.change(&m)
+		m.startViewJob()
+		if m.viewJob == nil {
+			t.Fatalf("%s: 没有在后台执行", c.name)
+		}
+		tm, _ := m.Update(keyMsg("esc"))
+		m = tm.(TableModel)
+
+		if m.viewJob != nil || m.viewPending {
+			t.Errorf("%s: 取消后仍有任务", c.name)
+		}
+		if filtersSignature(m.Filters) != filters || !sortKeysEqual(m.SortKeys, keys) || m.ShowDiffOnly {
+			t.Errorf("%s: 条件没有恢复: 筛选 %v，排序 %v，只看变更 %v", c.name, m.Filters, m.SortKeys, m.ShowDiffOnly)
+		}
+		if m.OriginalRows[0][0] != shown {
+			t.Errorf("%s: 显示结果变为 %v", c.name, m.OriginalRows[0])
+		}
+	}
+}
+
+// TestStaleViewResult 被新任务取代的结果被丢弃，只应用最后一次计算
+func TestStaleViewResult(t *testing.T) {
+	m := largeModel(t)
+	m.ToggleSort(0, false)
+	m.startViewJob()
+	stale := m.viewJob.gen
+	staleResult, _ := computeView(context.Background(), m.viewInput(true), nil)
+
+	m.ToggleSort(0, false)
+	m.startViewJob()
+	current := m.viewJob.gen
+	result, _ := computeView(context.Background(), m.viewInput(true), nil)
+
+	m.handleViewComputed(viewComputedMsg{gen: stale, result: staleResult})
+	if m.viewJob == nil || m.OriginalRows[0][0] != "0" {
+		t.Fatalf("应用了被取代的结果，首行为 %v", m.OriginalRows[0])
+	}
+	m.handleViewComputed(viewComputedMsg{gen: current, result: result})
+	if m.viewJob != nil || m.OriginalRows[0][0] != strconv.Itoa(asyncRowThreshold-1) {
+		t.Errorf("没有应用最后的结果，首行为 %v", m.OriginalRows[0])
+	}
+}